@@ -0,0 +1,232 @@
+// Package exactcover solves exact cover problems with Dancing Links (Knuth's Algorithm X).
+// see https://arxiv.org/abs/cs/0011047 for the original paper
+//
+// An exact cover problem is a 0/1 matrix; a solution is a set of rows with exactly one 1 in each primary column,
+// and at most one 1 in each secondary column.
+// Many puzzles can be encoded this way: Sudoku and its variants, Latin squares, polyomino tilings, N queens, etc.
+package exactcover
+
+import (
+	"context"
+	"fmt"
+)
+
+// a sparse 0/1 matrix stored as circular doubly-linked lists, with links stored as indexes into parallel slices
+// node 0 is the root; nodes 1 through numColumns are the column headers; the rest are the 1s in the matrix
+// only primary column headers are linked into the root's list, so the search never tries to cover secondary columns directly
+//
+// a Problem isn't safe for concurrent use, since searching temporarily modifies the links
+type Problem struct {
+	numColumns int
+	numRows    int
+
+	left   []int
+	right  []int
+	up     []int
+	down   []int
+	column []int // column header for each node
+
+	columnSize []int // number of nodes in each column, indexed by header node; used to pick the column with the fewest options
+	row        []int // index of the row each node belongs to, as returned by AddRow()
+}
+
+// creates a problem with no rows
+// columns 0 through numPrimaryColumns - 1 are primary columns, which must be covered exactly once;
+// the following numSecondaryColumns columns are secondary columns, which must be covered at most once
+func NewProblem(numPrimaryColumns int, numSecondaryColumns int) *Problem {
+	numColumns := numPrimaryColumns + numSecondaryColumns
+
+	p := &Problem{
+		numColumns: numColumns,
+	}
+
+	// root and column headers; each header starts as an empty vertical circle
+	for i := 0; i <= numColumns; i++ {
+		p.left = append(p.left, i)
+		p.right = append(p.right, i)
+		p.up = append(p.up, i)
+		p.down = append(p.down, i)
+		p.column = append(p.column, i)
+		p.columnSize = append(p.columnSize, 0)
+		p.row = append(p.row, -1)
+	}
+
+	// link the root and primary column headers in a horizontal circle; secondary headers are left linked to themselves
+	for header := 0; header <= numPrimaryColumns; header++ {
+		p.left[header] = header - 1
+		p.right[header] = header + 1
+	}
+	p.left[0] = numPrimaryColumns
+	p.right[numPrimaryColumns] = 0
+
+	return p
+}
+
+func (p *Problem) NumRows() int {
+	return p.numRows
+}
+
+// adds a row with 1s in the given columns, returning the row's index (rows are numbered from 0 in the order they're added)
+// panics if columns is empty, has duplicates, or has out-of-range columns, since any of those would corrupt the matrix
+func (p *Problem) AddRow(columns []int) int {
+	if len(columns) == 0 {
+		panic("can't add a row without any columns")
+	}
+
+	rowIndex := p.numRows
+	firstNode := -1
+
+	for i, col := range columns {
+		if col < 0 || col >= p.numColumns {
+			panic(fmt.Sprintf("column %v is out of range for a problem with %v columns", col, p.numColumns))
+		}
+		for _, otherCol := range columns[:i] {
+			if col == otherCol {
+				panic(fmt.Sprintf("column %v appears more than once in a row", col))
+			}
+		}
+
+		header := col + 1 // skip the root node
+		node := len(p.left)
+
+		// insert at the bottom of the column
+		p.up = append(p.up, p.up[header])
+		p.down = append(p.down, header)
+		p.down[p.up[header]] = node
+		p.up[header] = node
+		p.column = append(p.column, header)
+		p.columnSize[header]++
+		p.row = append(p.row, rowIndex)
+
+		// insert at the end of the row's horizontal circle
+		if firstNode == -1 {
+			firstNode = node
+			p.left = append(p.left, node)
+			p.right = append(p.right, node)
+		} else {
+			p.left = append(p.left, p.left[firstNode])
+			p.right = append(p.right, firstNode)
+			p.right[p.left[firstNode]] = node
+			p.left[firstNode] = node
+		}
+	}
+
+	p.numRows++
+	return rowIndex
+}
+
+// searches for every exact cover, calling onSolution with the indexes of each solution's rows
+// onSolution returns true to stop searching, false to keep looking for more solutions
+// the slice passed to onSolution is reused between calls, so copy it if it's needed after onSolution returns
+func (p *Problem) Solve(onSolution func(rows []int) bool) {
+	_ = p.SolveContext(context.Background(), onSolution)
+}
+
+// same as Solve(), but stops early (returning ctx's error) if ctx is done before the search finishes
+// the matrix is always restored to its original state, even if the search is stopped early
+func (p *Problem) SolveContext(ctx context.Context, onSolution func(rows []int) bool) error {
+	p.search(ctx, []int{}, onSolution)
+	return ctx.Err()
+}
+
+// finds the first exact cover, returning (rows, true), or (nil, false) if there isn't one
+func (p *Problem) FirstSolution() ([]int, bool) {
+	var solution []int
+
+	p.Solve(func(rows []int) bool {
+		solution = append([]int{}, rows...)
+		return true
+	})
+
+	return solution, solution != nil
+}
+
+// counts exact covers, stopping early once maxSolutions have been found
+func (p *Problem) CountSolutions(maxSolutions int) int {
+	if maxSolutions < 1 {
+		return 0
+	}
+
+	solutionCount := 0
+	p.Solve(func(_ []int) bool {
+		solutionCount++
+		return solutionCount >= maxSolutions
+	})
+
+	return solutionCount
+}
+
+// removes a column header from the header list, and every row with a 1 in that column from the other columns
+func (p *Problem) cover(header int) {
+	p.right[p.left[header]] = p.right[header]
+	p.left[p.right[header]] = p.left[header]
+
+	for rowNode := p.down[header]; rowNode != header; rowNode = p.down[rowNode] {
+		for node := p.right[rowNode]; node != rowNode; node = p.right[node] {
+			p.down[p.up[node]] = p.down[node]
+			p.up[p.down[node]] = p.up[node]
+			p.columnSize[p.column[node]]--
+		}
+	}
+}
+
+// exactly undoes cover(header); uncovering must happen in the reverse order of covering
+func (p *Problem) uncover(header int) {
+	for rowNode := p.up[header]; rowNode != header; rowNode = p.up[rowNode] {
+		for node := p.left[rowNode]; node != rowNode; node = p.left[node] {
+			p.columnSize[p.column[node]]++
+			p.down[p.up[node]] = node
+			p.up[p.down[node]] = node
+		}
+	}
+
+	p.right[p.left[header]] = header
+	p.left[p.right[header]] = header
+}
+
+// recursively searches for exact covers, extending partialSolution
+// returns true iff onSolution or ctx stopped the search
+func (p *Problem) search(ctx context.Context, partialSolution []int, onSolution func(rows []int) bool) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+
+	// every primary column is covered
+	if p.right[0] == 0 {
+		return onSolution(partialSolution)
+	}
+
+	// pick the column with the fewest remaining options, to keep the search tree small
+	chosenHeader := p.right[0]
+	for header := p.right[0]; header != 0; header = p.right[header] {
+		if p.columnSize[header] < p.columnSize[chosenHeader] {
+			chosenHeader = header
+		}
+	}
+
+	// no row can cover this column, so this branch is a dead end
+	if p.columnSize[chosenHeader] == 0 {
+		return false
+	}
+
+	p.cover(chosenHeader)
+	defer p.uncover(chosenHeader)
+
+	for rowNode := p.down[chosenHeader]; rowNode != chosenHeader; rowNode = p.down[rowNode] {
+		for node := p.right[rowNode]; node != rowNode; node = p.right[node] {
+			p.cover(p.column[node])
+		}
+
+		stop := p.search(ctx, append(partialSolution, p.row[rowNode]), onSolution)
+
+		for node := p.left[rowNode]; node != rowNode; node = p.left[node] {
+			p.uncover(p.column[node])
+		}
+
+		if stop {
+			return true
+		}
+	}
+
+	return false
+}
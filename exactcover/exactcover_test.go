@@ -0,0 +1,130 @@
+package exactcover_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/exactcover"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolve(t *testing.T) {
+	t.Run("Knuth's example from the Dancing Links paper", func(t *testing.T) {
+		// columns A through G are 0 through 6
+		problem := exactcover.NewProblem(7, 0)
+		problem.AddRow([]int{2, 4, 5}) // C E F
+		problem.AddRow([]int{0, 3, 6}) // A D G
+		problem.AddRow([]int{1, 2, 5}) // B C F
+		problem.AddRow([]int{0, 3})    // A D
+		problem.AddRow([]int{1, 6})    // B G
+		problem.AddRow([]int{3, 4, 6}) // D E G
+
+		solution, ok := problem.FirstSolution()
+		assert.True(t, ok)
+
+		slices.Sort(solution)
+		assert.EqualValues(t, []int{0, 3, 4}, solution)
+		assert.EqualValues(t, 1, problem.CountSolutions(10))
+	})
+
+	t.Run("A problem with no solution", func(t *testing.T) {
+		problem := exactcover.NewProblem(3, 0)
+		problem.AddRow([]int{0, 1})
+		problem.AddRow([]int{1, 2})
+
+		_, ok := problem.FirstSolution()
+		assert.False(t, ok)
+		assert.EqualValues(t, 0, problem.CountSolutions(10))
+	})
+
+	t.Run("Counting 4x4 Latin squares", func(t *testing.T) {
+		const n = 4
+
+		// primary columns: each cell has a symbol, each row has each symbol, each column has each symbol
+		problem := exactcover.NewProblem(3*n*n, 0)
+		for row := 0; row < n; row++ {
+			for col := 0; col < n; col++ {
+				for symbol := 0; symbol < n; symbol++ {
+					problem.AddRow([]int{
+						row*n + col,
+						n*n + row*n + symbol,
+						2*n*n + col*n + symbol,
+					})
+				}
+			}
+		}
+
+		assert.EqualValues(t, 576, problem.CountSolutions(1000))
+		assert.EqualValues(t, 100, problem.CountSolutions(100))
+	})
+
+	t.Run("Counting 8 queens solutions, using secondary columns for diagonals", func(t *testing.T) {
+		const n = 8
+		const numDiagonals = 2*n - 1
+
+		// primary columns: each rank and each file has exactly one queen
+		// secondary columns: each diagonal and anti-diagonal has at most one queen
+		problem := exactcover.NewProblem(2*n, 2*numDiagonals)
+		for rank := 0; rank < n; rank++ {
+			for file := 0; file < n; file++ {
+				problem.AddRow([]int{
+					rank,
+					n + file,
+					2*n + rank + file,
+					2*n + numDiagonals + rank - file + n - 1,
+				})
+			}
+		}
+
+		assert.EqualValues(t, 92, problem.CountSolutions(1000))
+	})
+
+	t.Run("Solving can be repeated, since searching restores the matrix", func(t *testing.T) {
+		problem := exactcover.NewProblem(2, 0)
+		problem.AddRow([]int{0})
+		problem.AddRow([]int{1})
+		problem.AddRow([]int{0, 1})
+
+		assert.EqualValues(t, 2, problem.CountSolutions(10))
+		assert.EqualValues(t, 2, problem.CountSolutions(10))
+	})
+
+	t.Run("Cancelling the context stops the search and restores the matrix", func(t *testing.T) {
+		problem := exactcover.NewProblem(4, 0)
+		for _, row := range [][]int{{0}, {1}, {2}, {3}, {0, 1}, {2, 3}} {
+			problem.AddRow(row)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		solutionCount := 0
+		err := problem.SolveContext(ctx, func(_ []int) bool {
+			solutionCount++
+			cancel()
+			return false
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.EqualValues(t, 1, solutionCount)
+		assert.EqualValues(t, 4, problem.CountSolutions(10))
+	})
+}
+
+func TestAddRow(t *testing.T) {
+	t.Run("Rows are numbered in the order they're added", func(t *testing.T) {
+		problem := exactcover.NewProblem(2, 1)
+
+		assert.EqualValues(t, 0, problem.AddRow([]int{0}))
+		assert.EqualValues(t, 1, problem.AddRow([]int{1, 2}))
+		assert.EqualValues(t, 2, problem.NumRows())
+	})
+
+	t.Run("Invalid rows panic", func(t *testing.T) {
+		problem := exactcover.NewProblem(2, 1)
+
+		assert.Panics(t, func() { problem.AddRow([]int{}) })
+		assert.Panics(t, func() { problem.AddRow([]int{3}) })
+		assert.Panics(t, func() { problem.AddRow([]int{-1}) })
+		assert.Panics(t, func() { problem.AddRow([]int{1, 1}) })
+	})
+}
@@ -1,6 +1,6 @@
 module github.com/DylanSp/sudoku-toolkit
 
-go 1.21.6
+go 1.22
 
 require (
 	github.com/samber/lo v1.39.0
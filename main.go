@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -30,8 +31,25 @@ func main() {
 		panic(err)
 	}
 
-	for _, grid := range grids {
-		solvedGrid := sudoku.SolveWithBasicStrategies(grid)
-		fmt.Println(solvedGrid.String())
+	solver, err := sudoku.NewSolver("strategies", sudoku.SolverOptions{})
+	// solver, err := sudoku.NewSolver("backtracking", sudoku.SolverOptions{Heuristic: sudoku.HouseBranching})
+	// solver, err := sudoku.NewSolver("dlx", sudoku.SolverOptions{})
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+
+	// 0 workers means one per CPU
+	batch := sudoku.SolveAll(context.Background(), solver, grids, 0)
+
+	for i, item := range batch.Items {
+		if item.Err != nil {
+			fmt.Printf("Unable to solve grid %v: %v\n", i, item.Err)
+			continue
+		}
+
+		fmt.Println(item.Result.Solutions[0].String())
 	}
+
+	fmt.Printf("Solved %v of %v grids in %v (%.1f grids/second)\n", batch.Solved, len(grids), batch.Elapsed, batch.Throughput())
 }
@@ -0,0 +1,485 @@
+package sat
+
+import (
+	"context"
+)
+
+// a conflict-driven clause learning (CDCL) SAT solver, in the style of MiniSat
+// see "An Extensible SAT-solver" by Eén and Sörensson for a description of the techniques used here:
+//   - unit propagation with two watched literals per clause
+//   - first-UIP conflict analysis, learning a clause from each conflict and backjumping
+//   - VSIDS-style variable activity for choosing decision variables, with phase saving
+//   - restarts following the Luby sequence
+//
+// learned clauses are never deleted, which keeps things simple and is fine for puzzle-sized formulas
+
+const (
+	// decay factor for variable activity; activities are effectively multiplied by this after each conflict
+	activityDecay = 0.95
+
+	// number of conflicts in the first restart interval; later intervals are multiples of this, following the Luby sequence
+	restartBase = 100
+)
+
+// finds a satisfying assignment for cnf, returning the literals that are true in it (one literal per variable, in order)
+// returns ErrUnsatisfiable if there isn't one
+func Solve(cnf *CNF) ([]Literal, error) {
+	return SolveContext(context.Background(), cnf)
+}
+
+// same as Solve(), but gives up (returning ctx's error) if ctx is done before the solver finishes
+// ctx is checked after every conflict, since the work between conflicts is bounded by the number of variables
+func SolveContext(ctx context.Context, cnf *CNF) ([]Literal, error) {
+	s := newCDCLSolver(cnf.NumVariables)
+
+	for _, clause := range cnf.Clauses {
+		if !s.addClause(clause) {
+			return nil, ErrUnsatisfiable
+		}
+	}
+
+	satisfiable, err := s.search(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !satisfiable {
+		return nil, ErrUnsatisfiable
+	}
+
+	return s.model(), nil
+}
+
+// internally, literals are encoded as 2 * variable for a positive literal and 2 * variable + 1 for a negative literal,
+// so they can be used as slice indexes, and negating a literal is just flipping the lowest bit
+type internalLiteral int
+
+func toInternal(literal Literal) internalLiteral {
+	if literal < 0 {
+		return internalLiteral(2*literal.Variable() + 1)
+	}
+
+	return internalLiteral(2 * literal.Variable())
+}
+
+func (l internalLiteral) variable() int {
+	return int(l >> 1)
+}
+
+func (l internalLiteral) negated() internalLiteral {
+	return l ^ 1
+}
+
+func (l internalLiteral) isNegative() bool {
+	return l&1 == 1
+}
+
+// values of variables and literals
+const (
+	valueFalse      int8 = -1
+	valueUnassigned int8 = 0
+	valueTrue       int8 = 1
+)
+
+// marks a literal as having been assigned by a decision or a unit clause, instead of being implied by a clause
+const noReason = -1
+
+type cdclSolver struct {
+	numVariables int
+
+	clauses [][]internalLiteral // original clauses followed by learned clauses; the first two literals of each are watched
+
+	// watches[l]: indexes of clauses watching literal l; they need to be checked when l becomes false
+	watches [][]int
+
+	assignment []int8 // per variable
+	level      []int  // decision level each variable was assigned at, per variable
+	reason     []int  // index of the clause that implied each variable's value, or noReason, per variable
+
+	trail          []internalLiteral // assigned literals, in the order they were assigned
+	trailLimits    []int             // trailLimits[i]: length of the trail when decision level i + 1 started
+	propagatedUpTo int               // literals in trail before this index have already been propagated
+
+	activity          []float64 // per variable; higher means the variable is more involved in recent conflicts
+	activityIncrement float64
+	savedPhase        []bool // the last value of each variable, used as its value when it's next picked for a decision
+
+	seen []bool // scratch space for conflict analysis, per variable
+}
+
+func newCDCLSolver(numVariables int) *cdclSolver {
+	// variables are numbered from 1, so per-variable slices have an unused entry at index 0
+	return &cdclSolver{
+		numVariables:      numVariables,
+		watches:           make([][]int, 2*(numVariables+1)),
+		assignment:        make([]int8, numVariables+1),
+		level:             make([]int, numVariables+1),
+		reason:            make([]int, numVariables+1),
+		activity:          make([]float64, numVariables+1),
+		activityIncrement: 1,
+		savedPhase:        make([]bool, numVariables+1),
+		seen:              make([]bool, numVariables+1),
+	}
+}
+
+func (s *cdclSolver) decisionLevel() int {
+	return len(s.trailLimits)
+}
+
+func (s *cdclSolver) literalValue(literal internalLiteral) int8 {
+	value := s.assignment[literal.variable()]
+	if literal.isNegative() {
+		return -value
+	}
+
+	return value
+}
+
+// adds an original clause; must be called before searching, when nothing but unit clauses has been assigned
+// returns false if the formula is now known to be unsatisfiable
+func (s *cdclSolver) addClause(clause Clause) bool {
+	literals := []internalLiteral{}
+
+	for _, literal := range clause {
+		internal := toInternal(literal)
+
+		switch {
+		case s.literalValue(internal) == valueTrue:
+			// already satisfied by a unit clause, so the clause can never be part of a conflict
+			return true
+		case s.literalValue(internal) == valueFalse:
+			// can never help satisfy the clause, so drop it
+			continue
+		}
+
+		duplicate := false
+		for _, existing := range literals {
+			if existing == internal.negated() {
+				// tautology (x OR NOT x), always satisfied
+				return true
+			}
+			if existing == internal {
+				duplicate = true
+			}
+		}
+
+		if !duplicate {
+			literals = append(literals, internal)
+		}
+	}
+
+	switch len(literals) {
+	case 0:
+		return false
+	case 1:
+		s.assign(literals[0], noReason)
+		return s.propagate() == noReason
+	default:
+		s.attachClause(literals)
+		return true
+	}
+}
+
+// stores a clause with at least two literals and watches its first two literals
+// returns the clause's index
+func (s *cdclSolver) attachClause(literals []internalLiteral) int {
+	clauseIndex := len(s.clauses)
+	s.clauses = append(s.clauses, literals)
+
+	s.watches[literals[0]] = append(s.watches[literals[0]], clauseIndex)
+	s.watches[literals[1]] = append(s.watches[literals[1]], clauseIndex)
+
+	return clauseIndex
+}
+
+// makes literal true at the current decision level
+func (s *cdclSolver) assign(literal internalLiteral, reason int) {
+	variable := literal.variable()
+
+	s.assignment[variable] = valueTrue
+	if literal.isNegative() {
+		s.assignment[variable] = valueFalse
+	}
+
+	s.level[variable] = s.decisionLevel()
+	s.reason[variable] = reason
+	s.trail = append(s.trail, literal)
+}
+
+// propagates all assignments on the trail that haven't been propagated yet, assigning any literals that become forced
+// returns the index of a conflicting clause (all literals false), or noReason if there's no conflict
+func (s *cdclSolver) propagate() int {
+	for s.propagatedUpTo < len(s.trail) {
+		falseLiteral := s.trail[s.propagatedUpTo].negated()
+		s.propagatedUpTo++
+
+		// filter the watch list in place, keeping only clauses that still watch falseLiteral
+		watchers := s.watches[falseLiteral]
+		kept := 0
+
+		for i := 0; i < len(watchers); i++ {
+			clauseIndex := watchers[i]
+			clause := s.clauses[clauseIndex]
+
+			// make sure the false literal is in position 1, so position 0 is the other watched literal
+			if clause[0] == falseLiteral {
+				clause[0], clause[1] = clause[1], clause[0]
+			}
+
+			// clause is already satisfied by the other watched literal
+			if s.literalValue(clause[0]) == valueTrue {
+				watchers[kept] = clauseIndex
+				kept++
+				continue
+			}
+
+			// look for a new literal to watch that isn't false
+			foundNewWatch := false
+			for k := 2; k < len(clause); k++ {
+				if s.literalValue(clause[k]) != valueFalse {
+					clause[1], clause[k] = clause[k], clause[1]
+					s.watches[clause[1]] = append(s.watches[clause[1]], clauseIndex)
+					foundNewWatch = true
+					break
+				}
+			}
+			if foundNewWatch {
+				continue
+			}
+
+			// every literal other than clause[0] is false, so the clause is either unit or conflicting
+			watchers[kept] = clauseIndex
+			kept++
+
+			if s.literalValue(clause[0]) == valueFalse {
+				// keep the remaining watchers, then report the conflict
+				kept += copy(watchers[kept:], watchers[i+1:])
+				s.watches[falseLiteral] = watchers[:kept]
+				return clauseIndex
+			}
+
+			s.assign(clause[0], clauseIndex)
+		}
+
+		s.watches[falseLiteral] = watchers[:kept]
+	}
+
+	return noReason
+}
+
+// finds the first unique implication point of a conflict
+// returns a learned clause, whose first literal will be the only unassigned one after backjumping,
+// and the decision level to backjump to
+func (s *cdclSolver) analyzeConflict(conflictClauseIndex int) ([]internalLiteral, int) {
+	learned := []internalLiteral{0} // position 0 is filled in with the asserting literal at the end
+
+	pathCount := 0 // number of seen literals from the current decision level that haven't been resolved yet
+	var resolvedLiteral internalLiteral
+	resolvedAny := false
+	trailIndex := len(s.trail) - 1
+	clause := s.clauses[conflictClauseIndex]
+
+	for {
+		// for reason clauses, position 0 is the implied literal being resolved on, so skip it
+		startIndex := 0
+		if resolvedAny {
+			startIndex = 1
+		}
+
+		for _, literal := range clause[startIndex:] {
+			variable := literal.variable()
+			if s.seen[variable] || s.level[variable] == 0 {
+				continue
+			}
+
+			s.seen[variable] = true
+			s.bumpActivity(variable)
+
+			if s.level[variable] == s.decisionLevel() {
+				pathCount++
+			} else {
+				learned = append(learned, literal)
+			}
+		}
+
+		// walk back along the trail to the most recent literal involved in the conflict
+		for !s.seen[s.trail[trailIndex].variable()] {
+			trailIndex--
+		}
+		resolvedLiteral = s.trail[trailIndex]
+		resolvedAny = true
+		trailIndex--
+
+		s.seen[resolvedLiteral.variable()] = false
+		pathCount--
+
+		if pathCount == 0 {
+			break
+		}
+
+		clause = s.clauses[s.reason[resolvedLiteral.variable()]]
+	}
+
+	learned[0] = resolvedLiteral.negated()
+
+	for _, literal := range learned[1:] {
+		s.seen[literal.variable()] = false
+	}
+
+	// backjump to the highest level among the other literals, and put that literal in position 1 so it's watched
+	backjumpLevel := 0
+	for i := 1; i < len(learned); i++ {
+		if s.level[learned[i].variable()] > backjumpLevel {
+			backjumpLevel = s.level[learned[i].variable()]
+			learned[1], learned[i] = learned[i], learned[1]
+		}
+	}
+
+	return learned, backjumpLevel
+}
+
+// undoes all assignments made after the given decision level
+func (s *cdclSolver) backtrack(level int) {
+	if s.decisionLevel() <= level {
+		return
+	}
+
+	for i := len(s.trail) - 1; i >= s.trailLimits[level]; i-- {
+		variable := s.trail[i].variable()
+		s.savedPhase[variable] = !s.trail[i].isNegative()
+		s.assignment[variable] = valueUnassigned
+		s.reason[variable] = noReason
+	}
+
+	s.trail = s.trail[:s.trailLimits[level]]
+	s.trailLimits = s.trailLimits[:level]
+	s.propagatedUpTo = len(s.trail)
+}
+
+func (s *cdclSolver) bumpActivity(variable int) {
+	s.activity[variable] += s.activityIncrement
+
+	// rescale everything to avoid overflowing float64
+	if s.activity[variable] > 1e100 {
+		for v := range s.activity {
+			s.activity[v] *= 1e-100
+		}
+		s.activityIncrement *= 1e-100
+	}
+}
+
+// makes future bumps worth more than past ones, which has the same effect as decaying all existing activities
+func (s *cdclSolver) decayActivities() {
+	s.activityIncrement /= activityDecay
+}
+
+// picks the unassigned variable with the highest activity, returning its literal with the saved phase
+// returns false if every variable is assigned
+func (s *cdclSolver) pickDecisionLiteral() (internalLiteral, bool) {
+	bestVariable := 0
+
+	// TODO - use a priority queue if this linear scan gets too slow for larger formulas
+	for variable := 1; variable <= s.numVariables; variable++ {
+		if s.assignment[variable] != valueUnassigned {
+			continue
+		}
+
+		if bestVariable == 0 || s.activity[variable] > s.activity[bestVariable] {
+			bestVariable = variable
+		}
+	}
+
+	if bestVariable == 0 {
+		return 0, false
+	}
+
+	literal := internalLiteral(2 * bestVariable)
+	if !s.savedPhase[bestVariable] {
+		literal = literal.negated()
+	}
+
+	return literal, true
+}
+
+// the main CDCL loop; returns true iff a satisfying assignment was found, or ctx's error if ctx is done first
+func (s *cdclSolver) search(ctx context.Context) (bool, error) {
+	restartCount := 0
+	conflictsUntilRestart := luby(restartCount) * restartBase
+
+	for {
+		conflictClauseIndex := s.propagate()
+
+		if conflictClauseIndex != noReason {
+			// a conflict without any decisions means the formula is unsatisfiable
+			if s.decisionLevel() == 0 {
+				return false, nil
+			}
+
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
+
+			learned, backjumpLevel := s.analyzeConflict(conflictClauseIndex)
+			s.backtrack(backjumpLevel)
+
+			if len(learned) == 1 {
+				s.assign(learned[0], noReason)
+			} else {
+				learnedIndex := s.attachClause(learned)
+				s.assign(learned[0], learnedIndex)
+			}
+
+			s.decayActivities()
+
+			conflictsUntilRestart--
+			if conflictsUntilRestart == 0 {
+				restartCount++
+				conflictsUntilRestart = luby(restartCount) * restartBase
+				s.backtrack(0)
+			}
+
+			continue
+		}
+
+		literal, ok := s.pickDecisionLiteral()
+		if !ok {
+			return true, nil
+		}
+
+		s.trailLimits = append(s.trailLimits, len(s.trail))
+		s.assign(literal, noReason)
+	}
+}
+
+// the current assignment, as a list of true literals
+func (s *cdclSolver) model() []Literal {
+	model := make([]Literal, 0, s.numVariables)
+
+	for variable := 1; variable <= s.numVariables; variable++ {
+		literal := Literal(variable)
+		if s.assignment[variable] == valueFalse {
+			literal = literal.Negated()
+		}
+		model = append(model, literal)
+	}
+
+	return model
+}
+
+// the ith element (0-indexed) of the Luby sequence: 1, 1, 2, 1, 1, 2, 4, 1, 1, 2, 1, 1, 2, 4, 8, ...
+func luby(i int) int {
+	// find the smallest complete subsequence (of length 2^k - 1) containing index i
+	size, power := 1, 1
+	for size < i+1 {
+		size = 2*size + 1
+		power *= 2
+	}
+
+	// descend into the half of the subsequence that contains i, until i is the last element of a subsequence
+	for size-1 != i {
+		size = (size - 1) / 2
+		power /= 2
+		i %= size
+	}
+
+	return power
+}
@@ -0,0 +1,155 @@
+package sat_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/DylanSp/sudoku-toolkit/sat"
+	"github.com/stretchr/testify/assert"
+)
+
+// checks satisfiability by trying every assignment; only feasible for a handful of variables
+func isSatisfiableByBruteForce(cnf *sat.CNF) bool {
+	for assignment := 0; assignment < 1<<cnf.NumVariables; assignment++ {
+		model := []sat.Literal{}
+		for variable := 1; variable <= cnf.NumVariables; variable++ {
+			literal := sat.Literal(variable)
+			if assignment&(1<<(variable-1)) == 0 {
+				literal = literal.Negated()
+			}
+			model = append(model, literal)
+		}
+
+		if cnf.IsSatisfiedBy(model) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// the pigeonhole principle: n+1 pigeons can't each go in a different one of n holes
+// famously hard for resolution-based solvers, so a good test of conflict analysis even at small sizes
+func pigeonholeCNF(numHoles int) sat.CNF {
+	numPigeons := numHoles + 1
+	variable := func(pigeon int, hole int) sat.Literal {
+		return sat.Literal(pigeon*numHoles + hole + 1)
+	}
+
+	cnf := sat.CNF{}
+
+	// each pigeon is in some hole
+	for pigeon := 0; pigeon < numPigeons; pigeon++ {
+		clause := []sat.Literal{}
+		for hole := 0; hole < numHoles; hole++ {
+			clause = append(clause, variable(pigeon, hole))
+		}
+		cnf.AddClause(clause...)
+	}
+
+	// no two pigeons share a hole
+	for hole := 0; hole < numHoles; hole++ {
+		for first := 0; first < numPigeons; first++ {
+			for second := first + 1; second < numPigeons; second++ {
+				cnf.AddClause(variable(first, hole).Negated(), variable(second, hole).Negated())
+			}
+		}
+	}
+
+	return cnf
+}
+
+func TestSolve(t *testing.T) {
+	t.Run("A satisfiable formula", func(t *testing.T) {
+		cnf := sat.CNF{}
+		cnf.AddClause(1, 2)
+		cnf.AddClause(-1, 3)
+		cnf.AddClause(-2, -3)
+		cnf.AddClause(-3, 4)
+
+		model, err := sat.Solve(&cnf)
+		assert.NoError(t, err)
+		assert.Len(t, model, 4)
+		assert.True(t, cnf.IsSatisfiedBy(model))
+	})
+
+	t.Run("A formula with conflicting unit clauses", func(t *testing.T) {
+		cnf := sat.CNF{}
+		cnf.AddClause(1)
+		cnf.AddClause(-1)
+
+		_, err := sat.Solve(&cnf)
+		assert.ErrorIs(t, err, sat.ErrUnsatisfiable)
+	})
+
+	t.Run("A formula with an empty clause", func(t *testing.T) {
+		cnf := sat.CNF{NumVariables: 1}
+		cnf.AddClause()
+
+		_, err := sat.Solve(&cnf)
+		assert.ErrorIs(t, err, sat.ErrUnsatisfiable)
+	})
+
+	t.Run("An empty formula", func(t *testing.T) {
+		cnf := sat.CNF{}
+
+		model, err := sat.Solve(&cnf)
+		assert.NoError(t, err)
+		assert.Empty(t, model)
+	})
+
+	t.Run("Pigeonhole formulas are unsatisfiable", func(t *testing.T) {
+		for numHoles := 1; numHoles <= 6; numHoles++ {
+			cnf := pigeonholeCNF(numHoles)
+
+			_, err := sat.Solve(&cnf)
+			assert.ErrorIs(t, err, sat.ErrUnsatisfiable)
+		}
+	})
+
+	t.Run("Solving gives up when the context is done", func(t *testing.T) {
+		// pigeonhole formulas take exponential time to refute with resolution, so this one won't be solved in time
+		cnf := pigeonholeCNF(12)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		startTime := time.Now()
+		_, err := sat.SolveContext(ctx, &cnf)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(startTime), 5*time.Second)
+	})
+
+	t.Run("Random 3-SAT formulas agree with brute force", func(t *testing.T) {
+		const numVariables = 12
+		rng := rand.New(rand.NewPCG(7, 8))
+
+		for range 200 {
+			cnf := sat.CNF{NumVariables: numVariables}
+
+			// around 4.26 clauses per variable is where random 3-SAT formulas are hardest, with about half satisfiable
+			numClauses := 40 + rng.IntN(25)
+			for range numClauses {
+				clause := []sat.Literal{}
+				for range 3 {
+					literal := sat.Literal(rng.IntN(numVariables) + 1)
+					if rng.IntN(2) == 0 {
+						literal = literal.Negated()
+					}
+					clause = append(clause, literal)
+				}
+				cnf.AddClause(clause...)
+			}
+
+			model, err := sat.Solve(&cnf)
+			if isSatisfiableByBruteForce(&cnf) {
+				assert.NoError(t, err)
+				assert.True(t, cnf.IsSatisfiedBy(model))
+			} else {
+				assert.ErrorIs(t, err, sat.ErrUnsatisfiable)
+			}
+		}
+	})
+}
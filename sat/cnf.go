@@ -0,0 +1,246 @@
+// Package sat represents boolean formulas in conjunctive normal form (CNF), and reads and writes them in the DIMACS format
+// used by most SAT solvers.
+// see http://www.satcompetition.org/2009/format-benchmarks2009.html for a description of the format
+package sat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// returned when a formula has no satisfying assignment
+var ErrUnsatisfiable = errors.New("formula is unsatisfiable")
+
+// a variable or its negation, using the DIMACS convention:
+// variables are numbered from 1, a positive literal is the variable itself, and a negative literal is its negation
+// 0 isn't a valid literal (DIMACS uses it to mark the end of a clause)
+type Literal int
+
+// the variable this literal refers to
+func (l Literal) Variable() int {
+	if l < 0 {
+		return int(-l)
+	}
+
+	return int(l)
+}
+
+func (l Literal) Negated() Literal {
+	return -l
+}
+
+// a disjunction of literals; satisfied if at least one of its literals is true
+type Clause []Literal
+
+// a conjunction of clauses; satisfied if every clause is satisfied
+type CNF struct {
+	NumVariables int
+	Clauses      []Clause
+
+	// optional comment lines, written at the start of the DIMACS output
+	Comments []string
+}
+
+// adds a clause made of the given literals, increasing NumVariables if the clause uses a new variable
+func (cnf *CNF) AddClause(literals ...Literal) {
+	for _, literal := range literals {
+		cnf.NumVariables = max(cnf.NumVariables, literal.Variable())
+	}
+
+	cnf.Clauses = append(cnf.Clauses, Clause(literals))
+}
+
+// checks if every clause has at least one literal that's true in the model
+// model lists the literals that are true; variables not mentioned in the model are treated as false
+func (cnf *CNF) IsSatisfiedBy(model []Literal) bool {
+	trueLiterals := map[Literal]bool{}
+	for _, literal := range model {
+		trueLiterals[literal] = true
+	}
+
+	isTrue := func(literal Literal) bool {
+		if literal > 0 {
+			return trueLiterals[literal]
+		}
+
+		// variables not in the model are false, so their negation is true unless the model says the variable is true
+		return !trueLiterals[literal.Negated()]
+	}
+
+	for _, clause := range cnf.Clauses {
+		satisfied := false
+		for _, literal := range clause {
+			if isTrue(literal) {
+				satisfied = true
+				break
+			}
+		}
+
+		if !satisfied {
+			return false
+		}
+	}
+
+	return true
+}
+
+// writes the CNF in DIMACS format: comment lines starting with "c", a "p cnf <variables> <clauses>" header,
+// then one clause per line, each terminated by 0
+func (cnf *CNF) WriteDIMACS(w io.Writer) error {
+	bufferedWriter := bufio.NewWriter(w)
+
+	for _, comment := range cnf.Comments {
+		fmt.Fprintf(bufferedWriter, "c %v\n", comment)
+	}
+
+	fmt.Fprintf(bufferedWriter, "p cnf %v %v\n", cnf.NumVariables, len(cnf.Clauses))
+
+	for _, clause := range cnf.Clauses {
+		for _, literal := range clause {
+			bufferedWriter.WriteString(strconv.Itoa(int(literal)))
+			bufferedWriter.WriteByte(' ')
+		}
+		bufferedWriter.WriteString("0\n")
+	}
+
+	return bufferedWriter.Flush()
+}
+
+// reads a CNF in DIMACS format
+// clauses may span multiple lines or share a line, as long as each one is terminated by 0
+func ParseDIMACS(r io.Reader) (CNF, error) {
+	cnf := CNF{}
+
+	scanner := bufio.NewScanner(r)
+	headerFound := false
+	declaredClauses := 0
+	currentClause := Clause{}
+
+lineLoop:
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "c"):
+			cnf.Comments = append(cnf.Comments, strings.TrimSpace(strings.TrimPrefix(line, "c")))
+			continue
+		// some benchmark files end with a "%" line; everything after it should be ignored
+		case strings.HasPrefix(line, "%"):
+			break lineLoop
+		case strings.HasPrefix(line, "p"):
+			if headerFound {
+				return CNF{}, fmt.Errorf("line %v: duplicate problem line", lineNumber)
+			}
+
+			fields := strings.Fields(line)
+			if len(fields) != 4 || fields[1] != "cnf" {
+				return CNF{}, fmt.Errorf("line %v: malformed problem line %q", lineNumber, line)
+			}
+
+			numVariables, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return CNF{}, fmt.Errorf("line %v: invalid number of variables: %w", lineNumber, err)
+			}
+			numClauses, err := strconv.Atoi(fields[3])
+			if err != nil {
+				return CNF{}, fmt.Errorf("line %v: invalid number of clauses: %w", lineNumber, err)
+			}
+
+			cnf.NumVariables = numVariables
+			declaredClauses = numClauses
+			headerFound = true
+			continue
+		}
+
+		if !headerFound {
+			return CNF{}, fmt.Errorf("line %v: clause before problem line", lineNumber)
+		}
+
+		for _, field := range strings.Fields(line) {
+			value, err := strconv.Atoi(field)
+			if err != nil {
+				return CNF{}, fmt.Errorf("line %v: invalid literal %q", lineNumber, field)
+			}
+
+			if value == 0 {
+				cnf.Clauses = append(cnf.Clauses, currentClause)
+				currentClause = Clause{}
+				continue
+			}
+
+			literal := Literal(value)
+			if literal.Variable() > cnf.NumVariables {
+				return CNF{}, fmt.Errorf("line %v: literal %v uses a variable beyond the declared %v", lineNumber, value, cnf.NumVariables)
+			}
+			currentClause = append(currentClause, literal)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return CNF{}, err
+	}
+
+	if !headerFound {
+		return CNF{}, fmt.Errorf("missing problem line")
+	}
+
+	// tolerate a missing 0 after the final clause
+	if len(currentClause) > 0 {
+		cnf.Clauses = append(cnf.Clauses, currentClause)
+	}
+
+	if len(cnf.Clauses) != declaredClauses {
+		return CNF{}, fmt.Errorf("problem line declares %v clauses, but found %v", declaredClauses, len(cnf.Clauses))
+	}
+
+	return cnf, nil
+}
+
+// reads a model (satisfying assignment) from a SAT solver's output, returning the literals that are true
+// understands both the SAT competition output format ("s SATISFIABLE", followed by "v ..." lines)
+// and MiniSat's result file format ("SAT", followed by a line of literals); in both cases, the literals end with 0
+// returns an error if the solver reported that the formula is unsatisfiable
+func ParseModel(r io.Reader) ([]Literal, error) {
+	model := []Literal{}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "c"):
+			continue
+		case line == "s UNSATISFIABLE" || line == "UNSAT":
+			return nil, ErrUnsatisfiable
+		case strings.HasPrefix(line, "s ") || line == "SAT":
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "v ")
+		for _, field := range strings.Fields(line) {
+			value, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %v: invalid literal %q", lineNumber, field)
+			}
+
+			if value == 0 {
+				return model, nil
+			}
+
+			model = append(model, Literal(value))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// tolerate a missing terminating 0
+	return model, nil
+}
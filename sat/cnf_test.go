@@ -0,0 +1,104 @@
+package sat_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDIMACS(t *testing.T) {
+	t.Run("Writing a CNF", func(t *testing.T) {
+		cnf := sat.CNF{
+			Comments: []string{"example"},
+		}
+		cnf.AddClause(1, -2)
+		cnf.AddClause(2, 3, -1)
+
+		var output bytes.Buffer
+		err := cnf.WriteDIMACS(&output)
+		assert.NoError(t, err)
+
+		expected := "c example\np cnf 3 2\n1 -2 0\n2 3 -1 0\n"
+		assert.EqualValues(t, expected, output.String())
+	})
+
+	t.Run("Round-tripping a CNF through DIMACS", func(t *testing.T) {
+		cnf := sat.CNF{}
+		cnf.AddClause(1, -2)
+		cnf.AddClause(-3)
+		cnf.AddClause(2, 3, 4)
+
+		var output bytes.Buffer
+		err := cnf.WriteDIMACS(&output)
+		assert.NoError(t, err)
+
+		parsed, err := sat.ParseDIMACS(&output)
+		assert.NoError(t, err)
+		assert.EqualValues(t, cnf.NumVariables, parsed.NumVariables)
+		assert.EqualValues(t, cnf.Clauses, parsed.Clauses)
+	})
+
+	t.Run("Parsing clauses that span lines", func(t *testing.T) {
+		input := "c comment\np cnf 3 2\n1 -2\n 3 0 -1 0\n%\n0\n"
+
+		parsed, err := sat.ParseDIMACS(strings.NewReader(input))
+		assert.NoError(t, err)
+		assert.EqualValues(t, []sat.Clause{{1, -2, 3}, {-1}}, parsed.Clauses)
+		assert.EqualValues(t, []string{"comment"}, parsed.Comments)
+	})
+
+	t.Run("Malformed input is rejected", func(t *testing.T) {
+		malformedInputs := []string{
+			"1 2 0\n",                   // no problem line
+			"p cnf 2\n1 2 0\n",          // malformed problem line
+			"p cnf 2 1\n1 3 0\n",        // variable out of range
+			"p cnf 2 2\n1 2 0\n",        // wrong number of clauses
+			"p cnf 2 1\n1 x 0\n",        // invalid literal
+			"p cnf 2 1\np cnf 2 1\n1 0", // duplicate problem line
+		}
+
+		for _, input := range malformedInputs {
+			_, err := sat.ParseDIMACS(strings.NewReader(input))
+			assert.Error(t, err, input)
+		}
+	})
+}
+
+func TestParseModel(t *testing.T) {
+	t.Run("SAT competition format", func(t *testing.T) {
+		input := "c solver output\ns SATISFIABLE\nv 1 -2 3\nv -4 0\n"
+
+		model, err := sat.ParseModel(strings.NewReader(input))
+		assert.NoError(t, err)
+		assert.EqualValues(t, []sat.Literal{1, -2, 3, -4}, model)
+	})
+
+	t.Run("MiniSat format", func(t *testing.T) {
+		input := "SAT\n-1 2 -3 0\n"
+
+		model, err := sat.ParseModel(strings.NewReader(input))
+		assert.NoError(t, err)
+		assert.EqualValues(t, []sat.Literal{-1, 2, -3}, model)
+	})
+
+	t.Run("Unsatisfiable results", func(t *testing.T) {
+		for _, input := range []string{"s UNSATISFIABLE\n", "UNSAT\n"} {
+			_, err := sat.ParseModel(strings.NewReader(input))
+			assert.ErrorIs(t, err, sat.ErrUnsatisfiable)
+		}
+	})
+}
+
+func TestIsSatisfiedBy(t *testing.T) {
+	cnf := sat.CNF{}
+	cnf.AddClause(1, 2)
+	cnf.AddClause(-1, 3)
+
+	assert.True(t, cnf.IsSatisfiedBy([]sat.Literal{1, -2, 3}))
+	assert.True(t, cnf.IsSatisfiedBy([]sat.Literal{2}))
+	assert.False(t, cnf.IsSatisfiedBy([]sat.Literal{1, 2, -3}))
+	assert.False(t, cnf.IsSatisfiedBy([]sat.Literal{}))
+}
@@ -0,0 +1,108 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// the outcome of solving one grid as part of a batch
+type BatchItem struct {
+	Result Result
+	Err    error // the error from solving this grid (if any); other grids in the batch are unaffected
+}
+
+// the results of solving a batch of grids with SolveAll()
+type BatchResult struct {
+	// Items[i] is the outcome for the ith grid passed to SolveAll()
+	Items []BatchItem
+
+	// how many grids were solved without an error, and how many weren't
+	Solved int
+	Failed int
+
+	Elapsed time.Duration
+}
+
+// grids processed (solved or failed) per second, over the whole batch
+func (b BatchResult) Throughput() float64 {
+	if b.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(len(b.Items)) / b.Elapsed.Seconds()
+}
+
+// solves every grid with solver, spreading the grids across a pool of workers goroutines; workers < 1 means one per CPU
+// results are in the same order as grids, however the work was split up
+// a grid that fails to solve (including by making the solver panic) only affects its own item
+// once ctx is done, grids that haven't been started yet fail with ctx's error
+// solver is shared by every worker, so its options' Observer (if any) must be safe to call from multiple goroutines
+func SolveAll(ctx context.Context, solver Solver, grids []Grid, workers int) BatchResult {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	startTime := time.Now()
+	batch := BatchResult{
+		Items: make([]BatchItem, len(grids)),
+	}
+
+	gridIndexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// each index is only ever sent to one worker, so workers never write to the same item
+			for i := range gridIndexes {
+				batch.Items[i] = solveBatchItem(ctx, solver, grids[i])
+			}
+		}()
+	}
+
+	for i := range grids {
+		gridIndexes <- i
+	}
+	close(gridIndexes)
+	wg.Wait()
+
+	for _, item := range batch.Items {
+		if item.Err == nil {
+			batch.Solved++
+		} else {
+			batch.Failed++
+		}
+	}
+	batch.Elapsed = time.Since(startTime)
+
+	return batch
+}
+
+// solves a single grid for SolveAll(), turning a panic into an error so one bad grid can't take down the whole batch
+func solveBatchItem(ctx context.Context, solver Solver, grid Grid) (item BatchItem) {
+	defer func() {
+		if r := recover(); r != nil {
+			item = BatchItem{
+				Err: fmt.Errorf("solver panicked: %v", r),
+			}
+		}
+	}()
+
+	if err := ctx.Err(); err != nil {
+		return BatchItem{
+			Result: Result{Progress: grid.Clone()},
+			Err:    err,
+		}
+	}
+
+	result, err := solver.Solve(ctx, grid)
+	return BatchItem{
+		Result: result,
+		Err:    err,
+	}
+}
@@ -0,0 +1,84 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+// a solver that panics on completely empty grids, and otherwise defers to the DLX solver
+type panickyEmptyGridSolver struct{}
+
+func (panickyEmptyGridSolver) Solve(ctx context.Context, grid sudoku.Grid) (sudoku.Result, error) {
+	emptyGrid := sudoku.EmptyGrid(2)
+	if grid.String() == emptyGrid.String() {
+		panic("empty grid")
+	}
+
+	return sudoku.DLXSolver{}.Solve(ctx, grid)
+}
+
+func TestSolveAll(t *testing.T) {
+	t.Run("Results are in the same order as the grids", func(t *testing.T) {
+		challenges := loadExampleGrids(t, "9x9", "hard95.txt")
+
+		batch := sudoku.SolveAll(context.Background(), sudoku.DLXSolver{}, challenges, 4)
+		assert.Len(t, batch.Items, len(challenges))
+		assert.EqualValues(t, len(challenges), batch.Solved)
+		assert.Zero(t, batch.Failed)
+		assert.Positive(t, batch.Throughput())
+
+		for i, item := range batch.Items {
+			assert.NoError(t, item.Err)
+
+			// each solution has to keep its own challenge's givens
+			assert.True(t, sudoku.IsSolutionOf(challenges[i], item.Result.Solutions[0]))
+		}
+	})
+
+	t.Run("Failures only affect their own grid", func(t *testing.T) {
+		grids := []sudoku.Grid{
+			sudoku.ParseSingleGrid("1......4..2..3.."),
+			sudoku.ParseSingleGrid("11.............."),
+			sudoku.EmptyGrid(2),
+			sudoku.ParseSingleGrid("1432321441232341"),
+		}
+
+		batch := sudoku.SolveAll(context.Background(), panickyEmptyGridSolver{}, grids, 0)
+		assert.EqualValues(t, 2, batch.Solved)
+		assert.EqualValues(t, 2, batch.Failed)
+
+		assert.NoError(t, batch.Items[0].Err)
+		assert.ErrorIs(t, batch.Items[1].Err, sudoku.ErrNoSolution)
+		assert.ErrorContains(t, batch.Items[2].Err, "panicked")
+		assert.NoError(t, batch.Items[3].Err)
+	})
+
+	t.Run("A cancelled context fails every grid", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		grids := loadExampleGrids(t, "9x9", "easy50.txt")
+		batch := sudoku.SolveAll(ctx, sudoku.DLXSolver{}, grids, 2)
+		assert.EqualValues(t, len(grids), batch.Failed)
+		for _, item := range batch.Items {
+			assert.ErrorIs(t, item.Err, context.Canceled)
+		}
+	})
+
+	t.Run("An empty batch", func(t *testing.T) {
+		batch := sudoku.SolveAll(context.Background(), sudoku.DLXSolver{}, []sudoku.Grid{}, 0)
+		assert.Empty(t, batch.Items)
+		assert.Zero(t, batch.Solved)
+	})
+}
+
+func BenchmarkSolveAll(b *testing.B) {
+	challenges := loadExampleGrids(b, "9x9", "hard95.txt")
+
+	for i := 0; i < b.N; i++ {
+		sudoku.SolveAll(context.Background(), sudoku.DLXSolver{}, challenges, 0)
+	}
+}
@@ -0,0 +1,170 @@
+package sudoku
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/DylanSp/sudoku-toolkit/utils"
+)
+
+// a grid along with the candidates (pencil marks) for each of its empty cells:
+// the values that the cell might still have, as far as the player or solver knows
+// cells that have a value have just that value as their only candidate, and their candidates can't be changed
+// use Clone() to copy a CandidateGrid; plain assignment shares the candidates between both copies
+type CandidateGrid struct {
+	// a Puzzle already tracks a grid with possible values for each cell, so reuse it, along with its methods for working with possibilities
+	puzzle Puzzle
+}
+
+// a single candidate value for a single cell
+type Candidate struct {
+	CellIndex int
+	Value     int
+}
+
+// creates a CandidateGrid for grid, where each empty cell's candidates are all values that none of its peers have
+func NewCandidateGrid(grid Grid) CandidateGrid {
+	puzzle := newPuzzle(grid)
+	puzzle.eliminatePossibilitiesByRules()
+
+	return CandidateGrid{puzzle: puzzle}
+}
+
+// creates a CandidateGrid from pencil marks entered by a player, which may or may not be correct
+// pencilMarks[i] is the list of candidates for the cell at index i; pencil marks for cells that have a value are ignored
+// returns an error if pencilMarks doesn't have an entry for each cell, or has a value that's out of range for grid
+func NewCandidateGridFromPencilMarks(grid Grid, pencilMarks [][]int) (CandidateGrid, error) {
+	if len(pencilMarks) != grid.numCells() {
+		return CandidateGrid{}, fmt.Errorf("got pencil marks for %v cells, but the grid has %v cells", len(pencilMarks), grid.numCells())
+	}
+
+	puzzle := newPuzzle(grid)
+
+	for cellIndex, marks := range pencilMarks {
+		if !grid.isEmptyAt(cellIndex) {
+			continue
+		}
+
+		candidates := utils.Set[int]{}
+		for _, value := range marks {
+			if value < 1 || value > grid.maxElement() {
+				return CandidateGrid{}, fmt.Errorf("pencil mark %v for cell %v is out of range", value, cellIndex)
+			}
+
+			candidates.Add(value)
+		}
+
+		puzzle.possibleValues[cellIndex] = candidates
+	}
+
+	return CandidateGrid{puzzle: puzzle}, nil
+}
+
+// returns a deep copy of cg; eliminating or restoring candidates in the copy doesn't affect cg, and vice versa
+func (cg CandidateGrid) Clone() CandidateGrid {
+	return CandidateGrid{puzzle: cg.puzzle.clone()}
+}
+
+// the grid the candidates are for
+func (cg CandidateGrid) Grid() Grid {
+	return cg.puzzle.underlyingGrid.grid()
+}
+
+// the candidates for the cell at cellIndex, in increasing order
+// for a cell with a value, the only candidate is that value
+func (cg CandidateGrid) Candidates(cellIndex int) []int {
+	return cg.puzzle.sortedPossibilities(cellIndex)
+}
+
+func (cg CandidateGrid) HasCandidate(cellIndex int, value int) bool {
+	return cg.puzzle.possibleValues[cellIndex].Has(value)
+}
+
+// removes value from the candidates of the (empty) cell at cellIndex
+// returns true iff value was a candidate beforehand; cells that have a value are never changed
+func (cg *CandidateGrid) Eliminate(cellIndex int, value int) bool {
+	return cg.puzzle.eliminatePossibility(cellIndex, value)
+}
+
+// adds value back to the candidates of the (empty) cell at cellIndex, undoing an elimination
+// returns true iff value wasn't a candidate beforehand; cells that have a value are never changed
+// panics if value is out of range for the grid
+func (cg *CandidateGrid) Restore(cellIndex int, value int) bool {
+	if value < 1 || value > cg.puzzle.underlyingGrid.maxElement() {
+		panic(fmt.Sprintf("value %v is out of range for a grid with side length %v", value, cg.puzzle.underlyingGrid.sideLength()))
+	}
+
+	if !cg.puzzle.isCellEmpty(cellIndex) || cg.HasCandidate(cellIndex, value) {
+		return false
+	}
+
+	cg.puzzle.possibleValues[cellIndex].Add(value)
+	return true
+}
+
+// checks if both candidate grids have the same grid, and the same candidates for every cell
+func (cg CandidateGrid) Equal(other CandidateGrid) bool {
+	if !cg.Grid().Equal(other.Grid()) {
+		return false
+	}
+
+	for i := range cg.puzzle.possibleValues {
+		if !slices.Equal(cg.Candidates(i), other.Candidates(i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// finds every candidate that cg is missing, compared to the candidates NewCandidateGrid() computes from the same grid, in order of cell index and value
+// these are eliminations that no peer's value explains; they're mistakes unless they came from a more advanced technique
+// this doesn't need a solution, so it can flag likely mistakes partway through a solve; see IncorrectEliminations() for a check that never flags a valid elimination
+func (cg CandidateGrid) UnexplainedEliminations() []Candidate {
+	return missingCandidates(cg, NewCandidateGrid(cg.Grid()))
+}
+
+// finds every candidate that cg still has, even though a peer already has that value, in order of cell index and value
+// these are eliminations the player hasn't made yet; they're never mistakes, but they make the candidates less useful
+func (cg CandidateGrid) StaleCandidates() []Candidate {
+	return missingCandidates(NewCandidateGrid(cg.Grid()), cg)
+}
+
+// the candidates that reference has but cg doesn't, in order of cell index and value; both must have the same grid
+func missingCandidates(cg CandidateGrid, reference CandidateGrid) []Candidate {
+	missing := []Candidate{}
+	for i := range cg.puzzle.possibleValues {
+		for _, value := range reference.Candidates(i) {
+			if !cg.HasCandidate(i, value) {
+				missing = append(missing, Candidate{
+					CellIndex: i,
+					Value:     value,
+				})
+			}
+		}
+	}
+
+	return missing
+}
+
+// finds every empty cell whose value in solution has been eliminated from its candidates, in order of cell index
+// these are the player's mistakes: eliminating a cell's real value means the candidates can never lead to the solution
+// unlike UnexplainedEliminations(), this needs the solution, but it never flags an elimination made by an advanced technique
+// returns an error if solution isn't a valid solution of cg's grid
+func (cg CandidateGrid) IncorrectEliminations(solution Grid) ([]Candidate, error) {
+	if !IsSolutionOf(cg.Grid(), solution) {
+		return nil, fmt.Errorf("%v isn't a solution of %v", solution.String(), cg.Grid().String())
+	}
+
+	incorrect := []Candidate{}
+	for i := range solution.numCells() {
+		if cg.puzzle.isCellEmpty(i) && !cg.HasCandidate(i, solution.valueAt(i)) {
+			incorrect = append(incorrect, Candidate{
+				CellIndex: i,
+				Value:     solution.valueAt(i),
+			})
+		}
+	}
+
+	return incorrect, nil
+}
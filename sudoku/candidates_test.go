@@ -0,0 +1,120 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandidateGrid(t *testing.T) {
+	// 1 . | . .
+	// . . | . 4
+	// ----+----
+	// . . | 2 .
+	// . 3 | . .
+	challenge := sudoku.ParseSingleGrid("1......4..2..3..")
+
+	t.Run("Candidates are computed from peers", func(t *testing.T) {
+		candidates := sudoku.NewCandidateGrid(challenge)
+		assert.True(t, candidates.Grid().Equal(challenge))
+
+		assert.EqualValues(t, []int{1}, candidates.Candidates(0))
+		assert.EqualValues(t, []int{2, 4}, candidates.Candidates(1))
+		assert.EqualValues(t, []int{2, 3}, candidates.Candidates(4))
+		assert.EqualValues(t, []int{2, 4}, candidates.Candidates(12))
+
+		assert.True(t, candidates.HasCandidate(1, 4))
+		assert.False(t, candidates.HasCandidate(1, 3))
+	})
+
+	t.Run("Eliminating and restoring candidates", func(t *testing.T) {
+		candidates := sudoku.NewCandidateGrid(challenge)
+
+		assert.True(t, candidates.Eliminate(1, 4))
+		assert.False(t, candidates.Eliminate(1, 4))
+		assert.EqualValues(t, []int{2}, candidates.Candidates(1))
+
+		assert.True(t, candidates.Restore(1, 4))
+		assert.False(t, candidates.Restore(1, 4))
+		assert.EqualValues(t, []int{2, 4}, candidates.Candidates(1))
+
+		// cells with values can't be changed
+		assert.False(t, candidates.Eliminate(0, 1))
+		assert.False(t, candidates.Restore(0, 2))
+		assert.EqualValues(t, []int{1}, candidates.Candidates(0))
+
+		assert.Panics(t, func() { candidates.Restore(1, 5) })
+	})
+
+	t.Run("Clones are independent of the original", func(t *testing.T) {
+		original := sudoku.NewCandidateGrid(challenge)
+		clone := original.Clone()
+		assert.True(t, clone.Equal(original))
+
+		clone.Eliminate(1, 4)
+		assert.EqualValues(t, []int{2, 4}, original.Candidates(1))
+		assert.False(t, clone.Equal(original))
+	})
+
+	t.Run("Creating candidates from pencil marks", func(t *testing.T) {
+		pencilMarks := make([][]int, 16)
+		pencilMarks[0] = []int{3} // ignored, since the cell has a value
+		pencilMarks[1] = []int{4, 2, 4}
+		pencilMarks[2] = []int{1, 2, 3, 4}
+
+		candidates, err := sudoku.NewCandidateGridFromPencilMarks(challenge, pencilMarks)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []int{1}, candidates.Candidates(0))
+		assert.EqualValues(t, []int{2, 4}, candidates.Candidates(1))
+		assert.EqualValues(t, []int{1, 2, 3, 4}, candidates.Candidates(2))
+		assert.Empty(t, candidates.Candidates(3))
+
+		_, err = sudoku.NewCandidateGridFromPencilMarks(challenge, pencilMarks[:15])
+		assert.Error(t, err)
+
+		pencilMarks[1] = []int{5}
+		_, err = sudoku.NewCandidateGridFromPencilMarks(challenge, pencilMarks)
+		assert.Error(t, err)
+	})
+
+	t.Run("Comparing with the computed candidates", func(t *testing.T) {
+		candidates := sudoku.NewCandidateGrid(challenge)
+		assert.Empty(t, candidates.UnexplainedEliminations())
+		assert.Empty(t, candidates.StaleCandidates())
+
+		// 3 isn't ruled out for cell 4 by any of its peers, but 1 is
+		candidates.Eliminate(4, 3)
+		candidates.Restore(4, 1)
+
+		assert.EqualValues(t, []sudoku.Candidate{
+			{CellIndex: 4, Value: 3},
+		}, candidates.UnexplainedEliminations())
+		assert.EqualValues(t, []sudoku.Candidate{
+			{CellIndex: 4, Value: 1},
+		}, candidates.StaleCandidates())
+	})
+
+	t.Run("Flagging incorrect eliminations", func(t *testing.T) {
+		solution := sudoku.ParseSingleGrid("1432321441232341")
+		candidates := sudoku.NewCandidateGrid(challenge)
+
+		incorrect, err := candidates.IncorrectEliminations(solution)
+		assert.NoError(t, err)
+		assert.Empty(t, incorrect)
+
+		// eliminating a value that's ruled out by the solution is fine, but eliminating the cell's real value isn't
+		candidates.Eliminate(1, 2)
+		candidates.Eliminate(4, 3)
+		candidates.Eliminate(12, 4)
+
+		incorrect, err = candidates.IncorrectEliminations(solution)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []sudoku.Candidate{
+			{CellIndex: 4, Value: 3},
+		}, incorrect)
+
+		_, err = candidates.IncorrectEliminations(sudoku.ParseSingleGrid("1234341221434321"))
+		assert.Error(t, err)
+	})
+}
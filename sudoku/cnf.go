@@ -0,0 +1,155 @@
+package sudoku
+
+import (
+	"fmt"
+
+	"github.com/DylanSp/sudoku-toolkit/sat"
+	"github.com/DylanSp/sudoku-toolkit/utils"
+)
+
+// which set of clauses to use when encoding a grid as CNF
+// see "Sudoku as a SAT Problem" by Lynce and Ouaknine for a comparison of the encodings
+type CNFEncoding int
+
+const (
+	// just enough clauses to specify the puzzle: every cell has at least one value,
+	// and every house has each value at most once
+	MinimalEncoding CNFEncoding = iota
+
+	// the minimal encoding plus redundant clauses that help SAT solvers propagate:
+	// every cell has at most one value, and every house has each value at least once
+	ExtendedEncoding
+)
+
+func (e CNFEncoding) String() string {
+	switch e {
+	case MinimalEncoding:
+		return "minimal"
+	case ExtendedEncoding:
+		return "extended"
+	default:
+		return "unknown"
+	}
+}
+
+// options for ToCNF()
+type CNFOptions struct {
+	Encoding CNFEncoding
+}
+
+// the SAT variable that's true iff the cell at cellIndex has value
+// variables are numbered from 1, so for a 9x9 grid, cell 0 uses variables 1-9, cell 1 uses variables 10-18, and so on
+func cellValueVariable(cellIndex int, value int, maxElement int) sat.Literal {
+	return sat.Literal(cellIndex*maxElement + value)
+}
+
+// encodes grid as a boolean formula in CNF, which is satisfiable iff the grid has a solution
+// each satisfying assignment corresponds to exactly one solution; use FromSATModel() to convert it back into a Grid
+// givens are encoded as unit clauses; use CNF.WriteDIMACS() to export the formula for an external SAT solver
+func ToCNF(grid Grid, options CNFOptions) sat.CNF {
+	maxElement := grid.maxElement()
+	variable := func(cellIndex int, value int) sat.Literal {
+		return cellValueVariable(cellIndex, value, maxElement)
+	}
+
+	gridKind := "Sudoku"
+	if !grid.HasBoxes() {
+		gridKind = "Latin square"
+	}
+
+	cnf := sat.CNF{
+		NumVariables: grid.numCells() * maxElement,
+		Comments: []string{
+			fmt.Sprintf("%vx%v %v, %v encoding", grid.sideLength(), grid.sideLength(), gridKind, options.Encoding),
+			fmt.Sprintf("variable (cellIndex * %v + value) is true iff the cell has that value; cells are numbered row by row from 0", maxElement),
+		},
+	}
+
+	allValues := make([]int, maxElement)
+	for i := range allValues {
+		allValues[i] = i + 1
+	}
+
+	// each cell has at least one value
+	for cellIndex := range grid.numCells() {
+		clause := []sat.Literal{}
+		for _, value := range allValues {
+			clause = append(clause, variable(cellIndex, value))
+		}
+		cnf.AddClause(clause...)
+	}
+
+	// each house has each value at most once
+	for _, house := range grid.houseIndexes() {
+		for _, value := range allValues {
+			for _, pair := range utils.Combinations(house, 2) {
+				cnf.AddClause(variable(pair[0], value).Negated(), variable(pair[1], value).Negated())
+			}
+		}
+	}
+
+	if options.Encoding == ExtendedEncoding {
+		// each cell has at most one value
+		for cellIndex := range grid.numCells() {
+			for _, pair := range utils.Combinations(allValues, 2) {
+				cnf.AddClause(variable(cellIndex, pair[0]).Negated(), variable(cellIndex, pair[1]).Negated())
+			}
+		}
+
+		// each house has each value at least once
+		for _, house := range grid.houseIndexes() {
+			for _, value := range allValues {
+				clause := []sat.Literal{}
+				for _, cellIndex := range house {
+					clause = append(clause, variable(cellIndex, value))
+				}
+				cnf.AddClause(clause...)
+			}
+		}
+	}
+
+	// givens
+	for cellIndex := range grid.numCells() {
+		if !grid.isEmptyAt(cellIndex) {
+			cnf.AddClause(variable(cellIndex, grid.valueAt(cellIndex)))
+		}
+	}
+
+	return cnf
+}
+
+// converts a satisfying assignment for a formula from ToCNF() back into a solved grid
+// model lists the literals that are true (negative literals are ignored), as returned by sat.ParseModel()
+// challenge is the grid that was passed to ToCNF(), which determines the solved grid's size and box shape
+// returns an error if the model doesn't give every cell exactly one value
+func FromSATModel(challenge Grid, model []sat.Literal) (Grid, error) {
+	grid := challenge.emptied().buffer()
+	maxElement := grid.maxElement()
+	numVariables := grid.numCells() * maxElement
+
+	for _, literal := range model {
+		if literal < 0 {
+			continue
+		}
+
+		if literal.Variable() > numVariables {
+			return Grid{}, fmt.Errorf("variable %v is out of range for a grid with %v variables", literal.Variable(), numVariables)
+		}
+
+		cellIndex := (literal.Variable() - 1) / maxElement
+		value := (literal.Variable()-1)%maxElement + 1
+
+		if !grid.isEmptyAt(cellIndex) {
+			return Grid{}, fmt.Errorf("model assigns multiple values to cell %v: %v and %v", cellIndex, grid.valueAt(cellIndex), value)
+		}
+		grid.setValueAt(cellIndex, value)
+	}
+
+	for cellIndex := range grid.numCells() {
+		if grid.isEmptyAt(cellIndex) {
+			return Grid{}, fmt.Errorf("model doesn't assign a value to cell %v", cellIndex)
+		}
+	}
+
+	return grid.grid(), nil
+}
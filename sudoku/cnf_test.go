@@ -0,0 +1,102 @@
+package sudoku_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sat"
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+// the model an external SAT solver would return for a solution: each cell's variable for its value is true, all others false
+func solutionToModel(t *testing.T, solution string, maxElement int) []sat.Literal {
+	t.Helper()
+
+	model := []sat.Literal{}
+	for cellIndex, ch := range solution {
+		cellValue := int(ch - '0')
+		for value := 1; value <= maxElement; value++ {
+			literal := sat.Literal(cellIndex*maxElement + value)
+			if value != cellValue {
+				literal = literal.Negated()
+			}
+			model = append(model, literal)
+		}
+	}
+
+	return model
+}
+
+func TestToCNF(t *testing.T) {
+	challenge := "4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......"
+	solution := "417369825632158947958724316825437169791586432346912758289643571573291684164875293"
+	wrongSolution := "127369845632158947958724316825437169791586432346912758289643571573291684164875293"
+
+	t.Run("Minimal encoding", func(t *testing.T) {
+		cnf := sudoku.ToCNF(sudoku.ParseSingleGrid(challenge), sudoku.CNFOptions{Encoding: sudoku.MinimalEncoding})
+
+		assert.EqualValues(t, 729, cnf.NumVariables)
+
+		// 81 cell clauses, 27 houses * 9 values * 36 pairs of cells, 17 givens
+		assert.Len(t, cnf.Clauses, 81+27*9*36+17)
+
+		assert.True(t, cnf.IsSatisfiedBy(solutionToModel(t, solution, 9)))
+		assert.False(t, cnf.IsSatisfiedBy(solutionToModel(t, wrongSolution, 9)))
+	})
+
+	t.Run("Extended encoding", func(t *testing.T) {
+		cnf := sudoku.ToCNF(sudoku.ParseSingleGrid(challenge), sudoku.CNFOptions{Encoding: sudoku.ExtendedEncoding})
+
+		// the minimal clauses, plus 81 cells * 36 pairs of values, and 27 houses * 9 values
+		assert.Len(t, cnf.Clauses, 81+27*9*36+17+81*36+27*9)
+
+		assert.True(t, cnf.IsSatisfiedBy(solutionToModel(t, solution, 9)))
+		assert.False(t, cnf.IsSatisfiedBy(solutionToModel(t, wrongSolution, 9)))
+	})
+
+	t.Run("Exporting and re-importing as DIMACS", func(t *testing.T) {
+		cnf := sudoku.ToCNF(sudoku.ParseSingleGrid(challenge), sudoku.CNFOptions{})
+
+		var output bytes.Buffer
+		err := cnf.WriteDIMACS(&output)
+		assert.NoError(t, err)
+
+		parsed, err := sat.ParseDIMACS(&output)
+		assert.NoError(t, err)
+		assert.EqualValues(t, cnf.Clauses, parsed.Clauses)
+	})
+}
+
+func TestFromSATModel(t *testing.T) {
+	t.Run("Converting a model back to a grid", func(t *testing.T) {
+		solution := "1432321441232341"
+
+		grid, err := sudoku.FromSATModel(sudoku.EmptyGrid(2), solutionToModel(t, solution, 4))
+		assert.NoError(t, err)
+		assert.EqualValues(t, solution, grid.String())
+	})
+
+	t.Run("A model with a cell missing a value is rejected", func(t *testing.T) {
+		model := solutionToModel(t, "1432321441232341", 4)
+		model[0] = model[0].Negated() // cell 0 no longer has value 1
+
+		_, err := sudoku.FromSATModel(sudoku.EmptyGrid(2), model)
+		assert.Error(t, err)
+	})
+
+	t.Run("A model with a cell with multiple values is rejected", func(t *testing.T) {
+		model := solutionToModel(t, "1432321441232341", 4)
+		model[1] = model[1].Negated() // cell 0 now has values 1 and 2
+
+		_, err := sudoku.FromSATModel(sudoku.EmptyGrid(2), model)
+		assert.Error(t, err)
+	})
+
+	t.Run("A model with an out-of-range variable is rejected", func(t *testing.T) {
+		model := append(solutionToModel(t, "1432321441232341", 4), 65)
+
+		_, err := sudoku.FromSATModel(sudoku.EmptyGrid(2), model)
+		assert.Error(t, err)
+	})
+}
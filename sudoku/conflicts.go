@@ -0,0 +1,91 @@
+package sudoku
+
+import (
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+// the kind of house a conflict is in
+type HouseKind int
+
+const (
+	_ HouseKind = iota // zero value means "no house kind specified"
+	RowHouse
+	ColumnHouse
+	BoxHouse
+)
+
+func (k HouseKind) String() string {
+	switch k {
+	case RowHouse:
+		return "row"
+	case ColumnHouse:
+		return "column"
+	case BoxHouse:
+		return "box"
+	default:
+		return "unspecified"
+	}
+}
+
+// a value that appears more than once in the same house
+type Conflict struct {
+	HouseKind HouseKind
+
+	// index of the row, column, or box within the grid, numbered from 0; boxes are numbered as in Grid.BoxOf()
+	HouseIndex int
+
+	Value int
+
+	// indexes of every cell in the house that has Value, in increasing order; always has at least 2 cells
+	CellIndexes []int
+}
+
+// finds every value that appears more than once in a row, column, or box of grid
+// works on partially filled grids; empty cells never conflict with anything
+// conflicts are listed for all rows, then all columns, then all boxes, and by increasing value within each house
+// a cell with a duplicated value is in a conflict for each of its houses that has the duplicate, so it can appear in several conflicts
+func Conflicts(grid Grid) []Conflict {
+	conflicts := []Conflict{}
+
+	houseGroups := []struct {
+		kind   HouseKind
+		houses [][]int
+	}{
+		{RowHouse, grid.rows()},
+		{ColumnHouse, grid.cols()},
+		{BoxHouse, grid.boxes()},
+	}
+
+	for _, group := range houseGroups {
+		for houseIndex, house := range group.houses {
+			cellsByValue := lo.GroupBy(house, grid.valueAt)
+
+			values := lo.Keys(cellsByValue)
+			slices.Sort(values)
+
+			for _, value := range values {
+				cellIndexes := cellsByValue[value]
+				if value == 0 || len(cellIndexes) < 2 {
+					continue
+				}
+
+				conflicts = append(conflicts, Conflict{
+					HouseKind:   group.kind,
+					HouseIndex:  houseIndex,
+					Value:       value,
+					CellIndexes: cellIndexes,
+				})
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// checks that no row, column, or box of grid has the same value more than once
+// unlike Grid.IsValidSolution(), this works on partially filled grids, but a consistent grid doesn't necessarily have a solution
+func IsConsistent(grid Grid) bool {
+	return len(Conflicts(grid)) == 0
+}
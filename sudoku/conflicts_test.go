@@ -0,0 +1,67 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflicts(t *testing.T) {
+	t.Run("Consistent grids have no conflicts", func(t *testing.T) {
+		for _, grid := range []sudoku.Grid{
+			sudoku.EmptyGrid(2),
+			sudoku.ParseSingleGrid("1......4..2..3.."),
+			sudoku.ParseSingleGrid("1432321441232341"),
+		} {
+			assert.Empty(t, sudoku.Conflicts(grid))
+			assert.True(t, sudoku.IsConsistent(grid))
+		}
+
+		for _, challenge := range loadExampleGrids(t, "9x9", "easy50.txt") {
+			assert.True(t, sudoku.IsConsistent(challenge))
+		}
+	})
+
+	t.Run("Reporting every conflict", func(t *testing.T) {
+		// 1 1 . .
+		// . . . .
+		// 1 . . 2
+		// . . . 2
+		grid := sudoku.ParseSingleGrid("11......1..2...2")
+
+		assert.False(t, sudoku.IsConsistent(grid))
+		assert.EqualValues(t, []sudoku.Conflict{
+			{HouseKind: sudoku.RowHouse, HouseIndex: 0, Value: 1, CellIndexes: []int{0, 1}},
+			{HouseKind: sudoku.ColumnHouse, HouseIndex: 0, Value: 1, CellIndexes: []int{0, 8}},
+			{HouseKind: sudoku.ColumnHouse, HouseIndex: 3, Value: 2, CellIndexes: []int{11, 15}},
+			{HouseKind: sudoku.BoxHouse, HouseIndex: 0, Value: 1, CellIndexes: []int{0, 1}},
+			{HouseKind: sudoku.BoxHouse, HouseIndex: 3, Value: 2, CellIndexes: []int{11, 15}},
+		}, sudoku.Conflicts(grid))
+	})
+
+	t.Run("A value appearing three times is a single conflict", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("3.33............")
+
+		assert.EqualValues(t, []sudoku.Conflict{
+			{HouseKind: sudoku.RowHouse, HouseIndex: 0, Value: 3, CellIndexes: []int{0, 2, 3}},
+			{HouseKind: sudoku.BoxHouse, HouseIndex: 1, Value: 3, CellIndexes: []int{2, 3}},
+		}, sudoku.Conflicts(grid))
+	})
+
+	t.Run("Latin squares only have row and column conflicts", func(t *testing.T) {
+		grid := sudoku.ParseLatinSquare("1....1..........")
+		assert.Empty(t, sudoku.Conflicts(grid))
+
+		grid = sudoku.ParseLatinSquare("1...1...........")
+		assert.EqualValues(t, []sudoku.Conflict{
+			{HouseKind: sudoku.ColumnHouse, HouseIndex: 0, Value: 1, CellIndexes: []int{0, 4}},
+		}, sudoku.Conflicts(grid))
+	})
+
+	t.Run("House kinds", func(t *testing.T) {
+		assert.EqualValues(t, "row", sudoku.RowHouse.String())
+		assert.EqualValues(t, "column", sudoku.ColumnHouse.String())
+		assert.EqualValues(t, "box", sudoku.BoxHouse.String())
+	})
+}
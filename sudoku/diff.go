@@ -0,0 +1,146 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// returned (wrapped with details) when a diff can't be applied to a grid, because the grid doesn't have the values the diff expects
+var ErrDiffMismatch = errors.New("diff doesn't match the grid")
+
+// what a change did to a cell
+type ChangeKind int
+
+const (
+	_           ChangeKind = iota // zero value means "no change kind specified"
+	Placement                     // an empty cell was given a value
+	Removal                       // a cell's value was removed, leaving it empty
+	Replacement                   // a cell's value was replaced with a different value
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Placement:
+		return "Placement"
+	case Removal:
+		return "Removal"
+	case Replacement:
+		return "Replacement"
+	default:
+		return "unspecified"
+	}
+}
+
+// a change to a single cell's value; values are 0 for an empty cell
+type CellChange struct {
+	CellIndex int
+
+	Before int
+	After  int
+}
+
+func (c CellChange) Kind() ChangeKind {
+	switch {
+	case c.Before == 0:
+		return Placement
+	case c.After == 0:
+		return Removal
+	default:
+		return Replacement
+	}
+}
+
+func (c CellChange) String() string {
+	return fmt.Sprintf("cell %v: %v -> %v", c.CellIndex, formatChangeValue(c.Before), formatChangeValue(c.After))
+}
+
+// like formatValue(), but prints values that don't have a symbol as plain numbers instead of panicking,
+// since changes built by hand can have any value (Apply() rejects them, but they should still be printable in its error messages and in logs)
+func formatChangeValue(value int) string {
+	if value < 0 || value > len(valueSymbols) {
+		return fmt.Sprint(value)
+	}
+
+	return formatValue(value)
+}
+
+// the differences between two grids with the same geometry, as a list of changes in order of cell index
+// cells that are the same in both grids aren't included, so diffs of similar grids are small enough to store as move histories
+type GridDiff []CellChange
+
+// the changes that gave an empty cell a value
+func (d GridDiff) Placements() []CellChange {
+	return d.ofKind(Placement)
+}
+
+// the changes that emptied a cell
+func (d GridDiff) Removals() []CellChange {
+	return d.ofKind(Removal)
+}
+
+// the changes that replaced one value with another
+func (d GridDiff) Replacements() []CellChange {
+	return d.ofKind(Replacement)
+}
+
+func (d GridDiff) ofKind(kind ChangeKind) []CellChange {
+	return lo.Filter(d, func(change CellChange, _ int) bool {
+		return change.Kind() == kind
+	})
+}
+
+// one change per line, for comparing grids in test failures and logs
+func (d GridDiff) String() string {
+	return strings.Join(lo.Map(d, func(change CellChange, _ int) string {
+		return change.String()
+	}), "\n")
+}
+
+// finds the changes that turn a into b
+// returns ErrGeometryMismatch if the grids' cells don't line up
+func Diff(a Grid, b Grid) (GridDiff, error) {
+	if !haveSameGeometry(a, b) {
+		return nil, ErrGeometryMismatch
+	}
+
+	diff := GridDiff{}
+	for i := range a.numCells() {
+		if a.valueAt(i) != b.valueAt(i) {
+			diff = append(diff, CellChange{
+				CellIndex: i,
+				Before:    a.valueAt(i),
+				After:     b.valueAt(i),
+			})
+		}
+	}
+
+	return diff, nil
+}
+
+// applies diff to grid, returning the changed grid; doesn't modify grid
+// so that a diff is never applied to the wrong grid by accident, each changed cell must have the diff's Before value
+// if any cell doesn't, or a change is out of range for grid, this returns an error wrapping ErrDiffMismatch, and no changes are made
+func Apply(grid Grid, diff GridDiff) (Grid, error) {
+	changed := grid.buffer()
+
+	for _, change := range diff {
+		if change.CellIndex < 0 || change.CellIndex >= grid.numCells() {
+			return grid, fmt.Errorf("%w: cell index %v is out of range for a grid with %v cells", ErrDiffMismatch, change.CellIndex, grid.numCells())
+		}
+
+		if change.After < 0 || change.After > grid.maxElement() {
+			return grid, fmt.Errorf("%w: value %v is out of range for a grid with side length %v", ErrDiffMismatch, change.After, grid.sideLength())
+		}
+
+		if changed.valueAt(change.CellIndex) != change.Before {
+			return grid, fmt.Errorf("%w: expected cell %v to be %v, but it's %v", ErrDiffMismatch, change.CellIndex, change.Before, changed.valueAt(change.CellIndex))
+		}
+
+		changed.setValueAt(change.CellIndex, change.After)
+	}
+
+	return changed.grid(), nil
+}
@@ -0,0 +1,119 @@
+package sudoku_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("Finding placements, removals, and replacements", func(t *testing.T) {
+		before := sudoku.ParseSingleGrid("1......4..2..3..")
+		after := sudoku.ParseSingleGrid("3.1....4.....3..")
+
+		diff, err := sudoku.Diff(before, after)
+		assert.NoError(t, err)
+		assert.EqualValues(t, sudoku.GridDiff{
+			{CellIndex: 0, Before: 1, After: 3},
+			{CellIndex: 2, Before: 0, After: 1},
+			{CellIndex: 10, Before: 2, After: 0},
+		}, diff)
+
+		assert.EqualValues(t, []sudoku.CellChange{{CellIndex: 2, Before: 0, After: 1}}, diff.Placements())
+		assert.EqualValues(t, []sudoku.CellChange{{CellIndex: 10, Before: 2, After: 0}}, diff.Removals())
+		assert.EqualValues(t, []sudoku.CellChange{{CellIndex: 0, Before: 1, After: 3}}, diff.Replacements())
+
+		assert.EqualValues(t, "cell 0: 1 -> 3\ncell 2: . -> 1\ncell 10: 2 -> .", diff.String())
+	})
+
+	t.Run("Printing changes with values that are out of range", func(t *testing.T) {
+		assert.EqualValues(t, "cell 0: -1 -> 36", sudoku.CellChange{CellIndex: 0, Before: -1, After: 36}.String())
+		assert.EqualValues(t, "cell 1: . -> A", sudoku.CellChange{CellIndex: 1, Before: 0, After: 10}.String())
+	})
+
+	t.Run("Identical grids have an empty diff", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("1......4..2..3..")
+
+		diff, err := sudoku.Diff(grid, grid)
+		assert.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("Showing what a solver filled in", func(t *testing.T) {
+		challenge := loadExampleGrids(t, "9x9", "easy50.txt")[0]
+		result, err := sudoku.DLXSolver{}.Solve(context.Background(), challenge)
+		assert.NoError(t, err)
+
+		diff, err := sudoku.Diff(challenge, result.Solutions[0])
+		assert.NoError(t, err)
+		assert.Len(t, diff.Placements(), strings.Count(challenge.String(), "."))
+		assert.Empty(t, diff.Removals())
+		assert.Empty(t, diff.Replacements())
+	})
+
+	t.Run("Grids with different shapes return an error", func(t *testing.T) {
+		_, err := sudoku.Diff(sudoku.EmptyGrid(2), sudoku.EmptyLatinSquare(4))
+		assert.ErrorIs(t, err, sudoku.ErrGeometryMismatch)
+	})
+}
+
+func TestApply(t *testing.T) {
+	before := sudoku.ParseSingleGrid("1......4..2..3..")
+	after := sudoku.ParseSingleGrid("3.1....4.....3..")
+
+	t.Run("Applying a diff", func(t *testing.T) {
+		diff, err := sudoku.Diff(before, after)
+		assert.NoError(t, err)
+
+		applied, err := sudoku.Apply(before, diff)
+		assert.NoError(t, err)
+		assert.True(t, applied.Equal(after))
+
+		// the original grid is unchanged
+		assert.EqualValues(t, "1......4..2..3..", before.String())
+	})
+
+	t.Run("Replaying a history of moves", func(t *testing.T) {
+		challenge := sudoku.ParseSingleGrid("1......4..2..3..")
+		moves := []sudoku.GridDiff{
+			{{CellIndex: 1, Before: 0, After: 4}},
+			{{CellIndex: 2, Before: 0, After: 3}, {CellIndex: 3, Before: 0, After: 2}},
+			{{CellIndex: 2, Before: 3, After: 0}},
+		}
+
+		grid := challenge
+		for _, move := range moves {
+			var err error
+			grid, err = sudoku.Apply(grid, move)
+			assert.NoError(t, err)
+		}
+
+		assert.EqualValues(t, "14.2...4..2..3..", grid.String())
+	})
+
+	t.Run("Diffs that don't match the grid return an error", func(t *testing.T) {
+		diff, err := sudoku.Diff(before, after)
+		assert.NoError(t, err)
+
+		// the diff has already been applied, so the Before values don't match
+		unchanged, err := sudoku.Apply(after, diff)
+		assert.ErrorIs(t, err, sudoku.ErrDiffMismatch)
+		assert.True(t, unchanged.Equal(after))
+
+		_, err = sudoku.Apply(before, sudoku.GridDiff{{CellIndex: 16, Before: 0, After: 1}})
+		assert.ErrorIs(t, err, sudoku.ErrDiffMismatch)
+
+		_, err = sudoku.Apply(before, sudoku.GridDiff{{CellIndex: 1, Before: 0, After: 5}})
+		assert.ErrorIs(t, err, sudoku.ErrDiffMismatch)
+	})
+}
+
+func TestChangeKinds(t *testing.T) {
+	assert.EqualValues(t, sudoku.Placement, sudoku.CellChange{Before: 0, After: 2}.Kind())
+	assert.EqualValues(t, sudoku.Removal, sudoku.CellChange{Before: 2, After: 0}.Kind())
+	assert.EqualValues(t, sudoku.Replacement, sudoku.CellChange{Before: 2, After: 3}.Kind())
+	assert.EqualValues(t, "Placement", sudoku.Placement.String())
+}
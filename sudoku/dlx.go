@@ -0,0 +1,78 @@
+package sudoku
+
+import (
+	"github.com/DylanSp/sudoku-toolkit/exactcover"
+)
+
+// a single row of the exact cover encoding of a grid: choosing value for the cell at cellIndex
+type exactCoverChoice struct {
+	cellIndex int
+	value     int
+}
+
+// encodes a grid as an exact cover problem, for solving with Dancing Links
+// each row of the matrix is a choice of a value for a cell; choices[i] describes row i
+// there are four groups of primary columns, each needing exactly one 1:
+//   - each cell has exactly one value
+//   - each row has each value exactly once
+//   - each column has each value exactly once
+//   - each box has each value exactly once (only for grids with boxes; Latin squares don't have this group)
+//
+// givens only get a matrix row for their value, so they're always part of the solution
+func gridToExactCover(grid Grid) (problem *exactcover.Problem, choices []exactCoverChoice) {
+	sideLength := grid.sideLength()
+	maxElement := grid.maxElement()
+	numCells := grid.numCells()
+
+	cellColumnsStart := 0
+	rowColumnsStart := numCells
+	colColumnsStart := rowColumnsStart + sideLength*maxElement
+	boxColumnsStart := colColumnsStart + sideLength*maxElement
+	numColumns := boxColumnsStart
+	if grid.HasBoxes() {
+		numColumns += sideLength * maxElement
+	}
+
+	problem = exactcover.NewProblem(numColumns, 0)
+	choices = []exactCoverChoice{}
+
+	for cellIndex := range numCells {
+		row := grid.RowOf(cellIndex)
+		col := grid.ColOf(cellIndex)
+		box := grid.BoxOf(cellIndex)
+
+		for value := 1; value <= maxElement; value++ {
+			if !grid.isEmptyAt(cellIndex) && grid.valueAt(cellIndex) != value {
+				continue
+			}
+
+			valueOffset := value - 1
+			columns := []int{
+				cellColumnsStart + cellIndex,
+				rowColumnsStart + row*maxElement + valueOffset,
+				colColumnsStart + col*maxElement + valueOffset,
+			}
+			if grid.HasBoxes() {
+				columns = append(columns, boxColumnsStart+box*maxElement+valueOffset)
+			}
+			problem.AddRow(columns)
+			choices = append(choices, exactCoverChoice{
+				cellIndex: cellIndex,
+				value:     value,
+			})
+		}
+	}
+
+	return problem, choices
+}
+
+// converts the rows of an exact cover back into a solved grid
+func exactCoverSolutionToGrid(challenge Grid, choices []exactCoverChoice, rows []int) Grid {
+	grid := challenge.emptied().buffer()
+
+	for _, row := range rows {
+		grid.setValueAt(choices[row].cellIndex, choices[row].value)
+	}
+
+	return grid.grid()
+}
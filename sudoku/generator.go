@@ -0,0 +1,183 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// options for generating a new challenge with Generate()
+type GeneratorOptions struct {
+	// seed for the random number generator; generating with the same base size and seed always produces the same challenge
+	// callers wanting a stream of different challenges should use a different seed for each one
+	Seed uint64
+
+	// the difficulty the challenge must have; the zero value accepts a challenge of any difficulty
+	Target DifficultyTarget
+
+	// how long to keep generating candidate challenges while looking for one that matches Target; 0 means no time limit
+	// the budget is checked while each candidate challenge is being generated too, so a single slow attempt can't overrun it by much
+	// note that some targets can never be met (for instance, a 4x4 challenge needing an X-Wing),
+	// so setting TimeBudget and/or MaxAttempts is recommended whenever Target is set
+	TimeBudget time.Duration
+
+	// how many candidate challenges to generate while looking for one that matches Target; 0 means no limit
+	MaxAttempts int
+
+	// the symmetry the pattern of givens must have; givens are removed a whole orbit at a time to preserve it
+	Symmetry Symmetry
+}
+
+// statistics about a call to GenerateWithStats()
+type GenerationStats struct {
+	// number of candidate challenges generated (including the returned one, if any)
+	Attempts int
+
+	// how many of the candidate challenges fell into each difficulty tier
+	AttemptsByDifficulty map[Difficulty]int
+
+	// rating of the returned challenge; only meaningful if generation succeeded
+	Rating Rating
+
+	Elapsed time.Duration
+}
+
+// returned when the time budget or maximum number of attempts runs out before finding a challenge matching the target
+var ErrGenerationBudgetExhausted = errors.New("couldn't generate a challenge matching the target within the budget")
+
+// generates a random challenge with a unique solution
+// starts from a random solved grid, then removes givens (in random order) as long as the challenge still has a unique solution,
+// so the resulting challenge is minimal - removing any other given would give it multiple solutions
+// (if options.Symmetry is set, it's only minimal with respect to removing symmetric groups of givens)
+// if options.Target is set, this repeats with new random solved grids until the challenge's rating matches the target
+func Generate(baseSize int, options GeneratorOptions) (Grid, error) {
+	challenge, _, err := GenerateWithStats(baseSize, options)
+	return challenge, err
+}
+
+// same as Generate(), but also returns statistics about the generation process, even if generation fails
+func GenerateWithStats(baseSize int, options GeneratorOptions) (Grid, GenerationStats, error) {
+	stats := GenerationStats{
+		AttemptsByDifficulty: map[Difficulty]int{},
+	}
+
+	// TODO - support larger grids once parsing/printing handles their values
+	if baseSize != 2 && baseSize != 3 {
+		return Grid{}, stats, fmt.Errorf("generating challenges with base size %v isn't supported", baseSize)
+	}
+
+	return generate(EmptyGrid(baseSize), options, stats)
+}
+
+// same as Generate(), but generates a challenge with boxes boxWidth columns wide and boxHeight rows tall (see EmptyGridWithBoxes())
+// only grids with side lengths up to 12 are supported
+func GenerateWithBoxes(boxWidth int, boxHeight int, options GeneratorOptions) (Grid, error) {
+	challenge, _, err := GenerateWithBoxesWithStats(boxWidth, boxHeight, options)
+	return challenge, err
+}
+
+// same as GenerateWithBoxes(), but also returns statistics about the generation process, even if generation fails
+func GenerateWithBoxesWithStats(boxWidth int, boxHeight int, options GeneratorOptions) (Grid, GenerationStats, error) {
+	stats := GenerationStats{
+		AttemptsByDifficulty: map[Difficulty]int{},
+	}
+
+	// larger grids take far too long to minimize
+	if boxWidth < 1 || boxHeight < 1 || boxWidth*boxHeight > 12 {
+		return Grid{}, stats, fmt.Errorf("generating challenges with %vx%v boxes isn't supported", boxWidth, boxHeight)
+	}
+
+	return generate(EmptyGridWithBoxes(boxWidth, boxHeight), options, stats)
+}
+
+// same as Generate(), but generates a Latin square challenge (see EmptyLatinSquare())
+// only side lengths from 2 to 9 are supported
+func GenerateLatinSquare(sideLength int, options GeneratorOptions) (Grid, error) {
+	challenge, _, err := GenerateLatinSquareWithStats(sideLength, options)
+	return challenge, err
+}
+
+// same as GenerateLatinSquare(), but also returns statistics about the generation process, even if generation fails
+func GenerateLatinSquareWithStats(sideLength int, options GeneratorOptions) (Grid, GenerationStats, error) {
+	stats := GenerationStats{
+		AttemptsByDifficulty: map[Difficulty]int{},
+	}
+
+	// larger Latin squares take far too long to minimize
+	if sideLength < 2 || sideLength > 9 {
+		return Grid{}, stats, fmt.Errorf("generating Latin squares with side length %v isn't supported", sideLength)
+	}
+
+	return generate(EmptyLatinSquare(sideLength), options, stats)
+}
+
+// generates challenges with the same size and geometry as empty, as described in Generate()
+func generate(empty Grid, options GeneratorOptions, stats GenerationStats) (Grid, GenerationStats, error) {
+	startTime := time.Now()
+	rng := newRandFromSeed(options.Seed)
+
+	// a single attempt can take a long time for larger grids, so the time budget also has to be able to stop one partway through
+	ctx := context.Background()
+	if options.TimeBudget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, startTime.Add(options.TimeBudget))
+		defer cancel()
+	}
+
+	for {
+		stats.Elapsed = time.Since(startTime)
+
+		budgetExhausted := (options.TimeBudget > 0 && stats.Elapsed >= options.TimeBudget) ||
+			(options.MaxAttempts > 0 && stats.Attempts >= options.MaxAttempts)
+		if budgetExhausted {
+			return Grid{}, stats, fmt.Errorf("%w after %v attempts in %v", ErrGenerationBudgetExhausted, stats.Attempts, stats.Elapsed)
+		}
+
+		challenge, err := generateCandidateChallenge(ctx, empty, rng, options.Target, options.Symmetry)
+		if err != nil {
+			// the time budget ran out partway through the attempt, which doesn't count since it didn't produce a challenge
+			stats.Elapsed = time.Since(startTime)
+			return Grid{}, stats, fmt.Errorf("%w after %v attempts in %v", ErrGenerationBudgetExhausted, stats.Attempts, stats.Elapsed)
+		}
+
+		stats.Attempts++
+		rating := Rate(challenge)
+		stats.AttemptsByDifficulty[rating.Difficulty]++
+
+		if options.Target.isMetBy(rating) {
+			stats.Rating = rating
+			stats.Elapsed = time.Since(startTime)
+			return challenge, stats, nil
+		}
+	}
+}
+
+// creates a random solved grid, then removes as many givens as possible while keeping the solution unique
+// a given is also kept if removing it would make the challenge harder than the target allows
+// givens are removed an orbit at a time, so the pattern of givens always has the requested symmetry
+// returns ctx's error if it's done before the challenge is finished
+func generateCandidateChallenge(ctx context.Context, empty Grid, rng *rand.Rand, target DifficultyTarget, symmetry Symmetry) (Grid, error) {
+	solution := randomSolution(empty, rng)
+	challenge := solution.Clone()
+
+	// rating is relatively expensive, so only do it when the target actually limits how hard the challenge can be
+	hasUpperLimit := (target.Difficulty != 0 && target.Difficulty != Extreme) || target.HardestAllowedTechnique != 0
+
+	orbits := symmetry.orbits(rng.Perm(challenge.numCells()), challenge.sideLength())
+	err := removeGivensWhileUnique(ctx, &challenge, orbits, func(challenge Grid) bool {
+		return !hasUpperLimit || !target.isExceededBy(Rate(challenge))
+	})
+	if err != nil {
+		return Grid{}, err
+	}
+
+	return challenge, nil
+}
+
+// creates a random number generator that always produces the same sequence for a given seed
+func newRandFromSeed(seed uint64) *rand.Rand {
+	// PCG needs two seed values; deriving the second from the first keeps the public API down to a single seed
+	return rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
@@ -0,0 +1,175 @@
+package sudoku_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("Generated challenges have a unique solution", func(t *testing.T) {
+		for _, baseSize := range []int{2, 3} {
+			for seed := uint64(0); seed < 3; seed++ {
+				challenge, err := sudoku.Generate(baseSize, sudoku.GeneratorOptions{Seed: seed})
+				assert.NoError(t, err)
+
+				assert.False(t, challenge.IsCompletelyFilled())
+				assert.True(t, sudoku.HasUniqueSolution(challenge))
+
+				solution := sudoku.SolveWithBacktracking(challenge)
+				assert.True(t, solution.IsValidSolution())
+			}
+		}
+	})
+
+	t.Run("Generating with the same seed gives the same challenge", func(t *testing.T) {
+		first, err := sudoku.Generate(3, sudoku.GeneratorOptions{Seed: 42})
+		assert.NoError(t, err)
+
+		second, err := sudoku.Generate(3, sudoku.GeneratorOptions{Seed: 42})
+		assert.NoError(t, err)
+
+		assert.EqualValues(t, first.String(), second.String())
+	})
+
+	t.Run("Generating with different seeds gives different challenges", func(t *testing.T) {
+		first, err := sudoku.Generate(3, sudoku.GeneratorOptions{Seed: 1})
+		assert.NoError(t, err)
+
+		second, err := sudoku.Generate(3, sudoku.GeneratorOptions{Seed: 2})
+		assert.NoError(t, err)
+
+		assert.NotEqualValues(t, first.String(), second.String())
+	})
+
+	t.Run("Unsupported base sizes return an error", func(t *testing.T) {
+		_, err := sudoku.Generate(1, sudoku.GeneratorOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestGenerateWithBoxes(t *testing.T) {
+	t.Run("Generated challenges have a unique solution", func(t *testing.T) {
+		for _, boxShape := range [][2]int{{3, 2}, {2, 3}, {4, 2}} {
+			challenge, err := sudoku.GenerateWithBoxes(boxShape[0], boxShape[1], sudoku.GeneratorOptions{Seed: 1})
+			assert.NoError(t, err)
+
+			assert.EqualValues(t, boxShape[0], challenge.BoxWidth())
+			assert.EqualValues(t, boxShape[1], challenge.BoxHeight())
+			assert.False(t, challenge.IsCompletelyFilled())
+			assert.True(t, sudoku.HasUniqueSolution(challenge))
+		}
+	})
+
+	t.Run("Unsupported box shapes return an error", func(t *testing.T) {
+		_, err := sudoku.GenerateWithBoxes(0, 3, sudoku.GeneratorOptions{})
+		assert.Error(t, err)
+
+		_, err = sudoku.GenerateWithBoxes(4, 4, sudoku.GeneratorOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestGenerateLatinSquare(t *testing.T) {
+	t.Run("Generated Latin squares have a unique solution", func(t *testing.T) {
+		for _, sideLength := range []int{3, 4, 5, 7} {
+			challenge, err := sudoku.GenerateLatinSquare(sideLength, sudoku.GeneratorOptions{Seed: 1})
+			assert.NoError(t, err)
+
+			assert.False(t, challenge.HasBoxes())
+			assert.EqualValues(t, sideLength, challenge.SideLength())
+			assert.False(t, challenge.IsCompletelyFilled())
+			assert.True(t, sudoku.HasUniqueSolution(challenge))
+		}
+	})
+
+	t.Run("Unsupported side lengths return an error", func(t *testing.T) {
+		_, err := sudoku.GenerateLatinSquare(1, sudoku.GeneratorOptions{})
+		assert.Error(t, err)
+
+		_, err = sudoku.GenerateLatinSquare(10, sudoku.GeneratorOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestGenerateWithStats(t *testing.T) {
+	t.Run("Generating a challenge with a target difficulty", func(t *testing.T) {
+		options := sudoku.GeneratorOptions{
+			Seed: 0,
+			Target: sudoku.DifficultyTarget{
+				Difficulty:              sudoku.Medium,
+				RequiredTechniques:      []sudoku.Technique{sudoku.LockedCandidates},
+				HardestAllowedTechnique: sudoku.HiddenPair,
+			},
+			MaxAttempts: 20,
+		}
+
+		challenge, stats, err := sudoku.GenerateWithStats(3, options)
+		assert.NoError(t, err)
+
+		assert.True(t, sudoku.HasUniqueSolution(challenge))
+
+		rating := sudoku.Rate(challenge)
+		assert.EqualValues(t, sudoku.Medium, rating.Difficulty)
+		assert.True(t, rating.Uses(sudoku.LockedCandidates))
+		assert.LessOrEqual(t, rating.HardestTechnique, sudoku.HiddenPair)
+		assert.EqualValues(t, rating, stats.Rating)
+
+		// the successful attempt was Medium, and every attempt is counted under its difficulty
+		assert.Positive(t, stats.Attempts)
+		assert.GreaterOrEqual(t, stats.AttemptsByDifficulty[sudoku.Medium], 1)
+
+		attemptsByDifficulty := 0
+		for _, attempts := range stats.AttemptsByDifficulty {
+			attemptsByDifficulty += attempts
+		}
+		assert.EqualValues(t, stats.Attempts, attemptsByDifficulty)
+	})
+
+	t.Run("Stats for other geometries", func(t *testing.T) {
+		challenge, stats, err := sudoku.GenerateWithBoxesWithStats(3, 2, sudoku.GeneratorOptions{Seed: 1})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, stats.Attempts)
+		assert.EqualValues(t, sudoku.Rate(challenge), stats.Rating)
+		assert.Positive(t, stats.Elapsed)
+
+		challenge, stats, err = sudoku.GenerateLatinSquareWithStats(4, sudoku.GeneratorOptions{Seed: 1})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, stats.Attempts)
+		assert.EqualValues(t, sudoku.Rate(challenge), stats.Rating)
+
+		// stats are returned even when the arguments are rejected
+		_, stats, err = sudoku.GenerateLatinSquareWithStats(10, sudoku.GeneratorOptions{})
+		assert.Error(t, err)
+		assert.Zero(t, stats.Attempts)
+	})
+
+	t.Run("Generation stops when the budget runs out", func(t *testing.T) {
+		// 4x4 challenges never need an X-Wing, so this target can't be met
+		options := sudoku.GeneratorOptions{
+			Target: sudoku.DifficultyTarget{
+				RequiredTechniques: []sudoku.Technique{sudoku.XWing},
+			},
+			MaxAttempts: 5,
+		}
+
+		_, stats, err := sudoku.GenerateWithStats(2, options)
+		assert.ErrorIs(t, err, sudoku.ErrGenerationBudgetExhausted)
+		assert.EqualValues(t, 5, stats.Attempts)
+	})
+
+	t.Run("The time budget can stop an attempt partway through", func(t *testing.T) {
+		// a single 12x12 attempt takes several seconds, most of it spent removing givens
+		options := sudoku.GeneratorOptions{
+			Seed:       1,
+			TimeBudget: 50 * time.Millisecond,
+		}
+
+		_, stats, err := sudoku.GenerateWithBoxesWithStats(4, 3, options)
+		assert.ErrorIs(t, err, sudoku.ErrGenerationBudgetExhausted)
+		assert.Zero(t, stats.Attempts)
+		assert.Less(t, stats.Elapsed, time.Second)
+	})
+}
@@ -1,195 +1,486 @@
 package sudoku
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"slices"
-	"strconv"
 	"strings"
 
-	"github.com/DylanSp/sudoku-toolkit/utils"
 	"github.com/samber/lo"
 )
 
+// a read-only view of a single cell, for convenience when inspecting a grid
+// a Cell holds a copy of its grid rather than a reference to it, so it's a snapshot:
+// changing the grid later doesn't affect cells that were already taken from it
 type Cell struct {
-	index          int   // index of this cell in containingGrid.cells
-	containingGrid *Grid // pointer to act as a reference, avoiding infinite loops; should never be nil
-	value          *int  // nil == no value
+	grid  Grid
+	index int // index of this cell in grid, numbering cells row by row from 0
+}
+
+func (c Cell) Index() int {
+	return c.index
+}
+
+func (c Cell) Row() int {
+	return c.grid.RowOf(c.index)
+}
+
+func (c Cell) Col() int {
+	return c.grid.ColOf(c.index)
+}
+
+// -1 for cells in a Latin square, since it has no boxes
+func (c Cell) Box() int {
+	return c.grid.BoxOf(c.index)
+}
+
+// the cell's value, or 0 if it's empty
+func (c Cell) Value() int {
+	return c.grid.valueAt(c.index)
 }
 
 // utility method for clarity
-func (c *Cell) isEmpty() bool {
-	return c.value == nil
+func (c Cell) IsEmpty() bool {
+	return c.Value() == 0
 }
 
-func (c *Cell) String() string {
-	if c.isEmpty() {
+func (c Cell) String() string {
+	return formatValue(c.Value())
+}
+
+// the symbol for a cell value, as used for printing and parsing grids; '.' for an empty cell (0)
+func formatValue(value int) string {
+	if value == 0 {
 		return "."
 	}
 
-	// TODO - generalize for different base sizes/ranges of possible values
-	if *c.value >= 1 && *c.value <= 9 {
-		return strconv.Itoa(*c.value)
+	if value >= 1 && value <= len(valueSymbols) {
+		return string(valueSymbols[value-1])
 	}
 
-	panic(fmt.Sprintf("don't know to print cell value %v", *c.value))
+	panic(fmt.Sprintf("don't know to print cell value %v", value))
 }
 
-// TODO - potential performance optimization - precalculate this for all cells when grid is created
-func (c *Cell) AllPeers() utils.Set[*Cell] {
-	peers := utils.Set[*Cell]{}
+// every cell that shares a row, column, or box (if its grid has boxes) with c, in order of their indexes
+func (c Cell) AllPeers() []Cell {
+	return lo.Map(c.grid.peerIndexes(c.index), func(peerIndex int, _ int) Cell {
+		return c.grid.Cell(peerIndex)
+	})
+}
 
-	indexes := c.allPeerIndexes()
-	for _, peerIndex := range indexes.Elements() {
-		peers.Add(c.containingGrid.cells[peerIndex])
-	}
+// symbols for printing and parsing cell values: digits for 1-9, then letters for larger values (A = 10, B = 11, and so on)
+const valueSymbols = "123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
-	return peers
+// a Sudoku grid: its geometry, plus the value (if any) of each cell
+// grids are values: copying a grid is cheap and the copy never shares state with the original,
+// and grids can be compared with == and used as map keys
+// a grid can also be a Latin square, which has rows and columns but no boxes (see EmptyLatinSquare())
+type Grid struct {
+	// the number of cells in each row and column
+	size int
+
+	// the number of columns and rows in each box; for example, a 9x9 grid has 3x3 boxes, and a 6x6 grid usually has 3x2 boxes
+	// each row of the grid passes through boxHeight boxes, and each column through boxWidth boxes
+	// both are 0 for Latin squares, which have no boxes
+	// invariant: for grids with boxes, boxWidth * boxHeight == size
+	boxWidth  int
+	boxHeight int
+
+	// one byte per cell, row-by-row, holding the cell's value, with 0 for an empty cell
+	// a string rather than a []uint8 so that Grid is comparable, and so that copies can't alias each other
+	// (code that changes many cells works on a gridBuffer, and converts it back to a Grid when it's done)
+	// invariant: len(values) == sideLength() ^ 2
+	values string
+}
+
+// creates an empty grid with square boxes, baseSize cells on each side
+func EmptyGrid(baseSize int) Grid {
+	// example: a 9x9 Sudoku has baseSize 3: each side of the grid is 3*3 = 9 cells
+	return EmptyGridWithBoxes(baseSize, baseSize)
 }
 
-func (c *Cell) allPeerIndexes() utils.Set[int] {
-	allPeerIndexes := utils.Set[int]{}
+// creates an empty grid with boxes boxWidth columns wide and boxHeight rows tall
+// for example, EmptyGridWithBoxes(3, 2) creates a 6x6 grid with 3x2 boxes
+// the grid's side length is boxWidth * boxHeight, so every row, column, and box has the same number of cells
+func EmptyGridWithBoxes(boxWidth int, boxHeight int) Grid {
+	if boxWidth < 1 || boxHeight < 1 {
+		panic(fmt.Sprintf("boxes must be at least 1x1, not %vx%v", boxWidth, boxHeight))
+	}
 
-	// we can't inline these into "range c.rowPeerIndexes().Elements()" because .Elements() has a pointer receiver,
-	// so we need an intermediate variable for each group of indexes
+	sideLength := boxWidth * boxHeight
+	return Grid{
+		size:      sideLength,
+		boxWidth:  boxWidth,
+		boxHeight: boxHeight,
+		values:    strings.Repeat("\x00", sideLength*sideLength),
+	}
+}
 
-	// TODO - potentially combine the loops in c.rowPeerIndexes() and c.colPeerIndexes()?
-	// we can use a divmod-like function: div, mod := i / sideLength, i % sideLength
+// creates an empty Latin square, sideLength cells on each side
+// a Latin square is like a Sudoku grid without boxes: each row and column must have each value from 1 to sideLength exactly once
+// Latin squares work with everything that works with Sudoku grids, including the solvers, CountSolutions(), and the strategies
+func EmptyLatinSquare(sideLength int) Grid {
+	if sideLength < 1 {
+		panic(fmt.Sprintf("a Latin square must have at least 1 cell on each side, not %v", sideLength))
+	}
 
-	rowPeerIndexes := c.rowPeerIndexes()
-	for _, peerIndex := range rowPeerIndexes.Elements() {
-		allPeerIndexes.Add(peerIndex)
+	return Grid{
+		size:   sideLength,
+		values: strings.Repeat("\x00", sideLength*sideLength),
 	}
+}
 
-	colPeerIndexes := c.colPeerIndexes()
-	for _, peerIndex := range colPeerIndexes.Elements() {
-		allPeerIndexes.Add(peerIndex)
+// the usual box shape for a grid with the given side length: the most square boxes that fit, wider than they are tall
+// for example, 3x3 boxes for a 9x9 grid, 3x2 boxes for a 6x6 grid, and 4x3 boxes for a 12x12 grid
+// returns an error if the side length can't be split into boxes with more than one row, such as for prime side lengths
+func defaultBoxShape(sideLength int) (boxWidth int, boxHeight int, err error) {
+	for height := 2; height*height <= sideLength; height++ {
+		if sideLength%height == 0 {
+			boxHeight = height
+		}
 	}
 
-	boxPeerIndexes := c.boxPeerIndexes()
-	for _, peerIndex := range boxPeerIndexes.Elements() {
-		allPeerIndexes.Add(peerIndex)
+	if boxHeight == 0 {
+		return 0, 0, fmt.Errorf("a grid with side length %v can't be split into boxes", sideLength)
 	}
 
-	return allPeerIndexes
+	return sideLength / boxHeight, boxHeight, nil
 }
 
-// indexes of all cells that are in the same row as c
-func (c *Cell) rowPeerIndexes() utils.Set[int] {
-	peerIndexes := utils.Set[int]{}
+// creates a grid from its values, row by row, with 0 for an empty cell
+// values must be square, and every value must be between 0 and the side length
+// the grid's boxes have the usual shape for its size: 3x3 for a 9x9 grid, 3x2 for a 6x6 grid, 4x3 for a 12x12 grid, and so on
+// use NewGridFromValuesWithBoxes() for other box shapes, or for Latin squares
+func NewGridFromValues(values [][]int) (Grid, error) {
+	boxWidth, boxHeight, err := defaultBoxShape(len(values))
+	if err != nil {
+		return Grid{}, err
+	}
 
-	for i := range c.containingGrid.cells {
-		// c isn't its own peer
-		if i == c.index {
-			continue
+	return NewGridFromValuesWithBoxes(values, boxWidth, boxHeight)
+}
+
+// creates a grid from its values like NewGridFromValues(), but with boxes boxWidth columns wide and boxHeight rows tall
+// boxWidth and boxHeight must both be 0 for a Latin square; otherwise, their product must be the side length
+func NewGridFromValuesWithBoxes(values [][]int, boxWidth int, boxHeight int) (Grid, error) {
+	sideLength := len(values)
+
+	if sideLength == 0 {
+		return Grid{}, errors.New("a grid must have at least one row")
+	}
+
+	isLatinSquare := boxWidth == 0 && boxHeight == 0
+	if !isLatinSquare && (boxWidth < 1 || boxHeight < 1 || boxWidth*boxHeight != sideLength) {
+		return Grid{}, fmt.Errorf("a grid with side length %v can't have %vx%v boxes", sideLength, boxWidth, boxHeight)
+	}
+
+	buffer := EmptyLatinSquare(sideLength).buffer()
+
+	for row, rowValues := range values {
+		if len(rowValues) != sideLength {
+			return Grid{}, fmt.Errorf("row %v has %v values, but should have %v", row, len(rowValues), sideLength)
 		}
 
-		// integer division, rounding down
-		if i/c.containingGrid.sideLength() == c.index/c.containingGrid.sideLength() {
-			peerIndexes.Add(i)
+		for col, value := range rowValues {
+			if value < 0 || value > sideLength {
+				return Grid{}, fmt.Errorf("value %v at row %v, column %v is out of range", value, row, col)
+			}
+
+			buffer.setValueAt(row*sideLength+col, value)
 		}
 	}
 
-	return peerIndexes
+	grid := buffer.grid()
+	grid.boxWidth, grid.boxHeight = boxWidth, boxHeight
+	return grid, nil
 }
 
-// indexes of all cells that are in the same column as c
-func (c *Cell) colPeerIndexes() utils.Set[int] {
-	peerIndexes := utils.Set[int]{}
+// returns a copy of g; since grids are values, this is the same as assigning g to another variable,
+// but it makes it clear to readers that the copy is about to be changed independently of g
+func (g Grid) Clone() Grid {
+	return g
+}
 
-	for i := range c.containingGrid.cells {
-		// c isn't its own peer
-		if i == c.index {
-			continue
-		}
+func (g Grid) sideLength() int {
+	return g.size
+}
 
-		if i%c.containingGrid.sideLength() == c.index%c.containingGrid.sideLength() {
-			peerIndexes.Add(i)
-		}
+// the size of each box's side, for grids with square boxes; for example, (3, true) for a 9x9 grid
+// returns (0, false) if the boxes aren't square (like a 6x6 grid's 3x2 boxes), or the grid has no boxes;
+// BoxWidth() and BoxHeight() work for every grid
+func (g Grid) BaseSize() (int, bool) {
+	if !g.HasBoxes() || g.boxWidth != g.boxHeight {
+		return 0, false
 	}
 
-	return peerIndexes
+	return g.boxWidth, true
 }
 
-// indexes of all cells that are in the same box as c
-func (c *Cell) boxPeerIndexes() utils.Set[int] {
-	// unsure if there's an easy arithmetic way to find this
-	// instead, loop through all boxes in the grid, find which one contains c, then loop through its contents
+// the number of columns in each box, or 0 for a Latin square
+func (g Grid) BoxWidth() int {
+	return g.boxWidth
+}
 
-	peerIndexes := utils.Set[int]{}
+// the number of rows in each box, or 0 for a Latin square
+func (g Grid) BoxHeight() int {
+	return g.boxHeight
+}
 
-	var boxContainingC []*Cell
+// false for Latin squares, true for Sudoku grids
+func (g Grid) HasBoxes() bool {
+	return g.boxWidth != 0
+}
 
-	// should always find and assign a value for boxContainingC
-	for _, box := range c.containingGrid.boxes() {
-		if slices.ContainsFunc(box, func(cell *Cell) bool {
-			return cell.index == c.index
-		}) {
-			boxContainingC = box
-			break
-		}
+// the number of cells in each row, column, and box; also the largest value a cell can have
+func (g Grid) SideLength() int {
+	return g.sideLength()
+}
+
+// checks if two grids have the same size and the same value in every cell; equivalent to g == other
+func (g Grid) Equal(other Grid) bool {
+	return g == other
+}
+
+// the value of the cell at (row, col), or 0 if it's empty
+// rows and columns are numbered from 0; panics if either is out of range
+func (g Grid) Get(row int, col int) int {
+	return g.valueAt(g.cellIndex(row, col))
+}
+
+// sets the value of the cell at (row, col), replacing any existing value
+// panics if row, col, or value is out of range; use Clear() to empty a cell
+func (g *Grid) Set(row int, col int, value int) {
+	if value < 1 || value > g.maxElement() {
+		panic(fmt.Sprintf("value %v is out of range for a grid with side length %v", value, g.sideLength()))
 	}
 
-	for _, cellInBox := range boxContainingC {
-		// c isn't its own peer
-		if cellInBox.index == c.index {
-			continue
-		}
+	g.setValueAt(g.cellIndex(row, col), value)
+}
+
+// empties the cell at (row, col); panics if either is out of range
+func (g *Grid) Clear(row int, col int) {
+	g.setValueAt(g.cellIndex(row, col), 0)
+}
+
+// the row containing the cell at cellIndex (cells are numbered row by row, from 0)
+func (g Grid) RowOf(cellIndex int) int {
+	return cellIndex / g.sideLength()
+}
 
-		peerIndexes.Add(cellInBox.index)
+// the column containing the cell at cellIndex (cells are numbered row by row, from 0)
+func (g Grid) ColOf(cellIndex int) int {
+	return cellIndex % g.sideLength()
+}
+
+// the box containing the cell at cellIndex (cells are numbered row by row, from 0)
+// boxes are numbered row by row as well, so in a 9x9 grid, box 0 is the top-left box and box 5 is the middle-right box
+// returns -1 for Latin squares, since they have no boxes
+func (g Grid) BoxOf(cellIndex int) int {
+	if !g.HasBoxes() {
+		return -1
 	}
 
-	return peerIndexes
+	// each band of boxes (the boxes sharing a set of rows) has boxHeight boxes in it
+	band := g.RowOf(cellIndex) / g.boxHeight
+	stack := g.ColOf(cellIndex) / g.boxWidth
+	return band*g.boxHeight + stack
 }
 
-type Grid struct {
-	baseSize int
+// a view of the cell at cellIndex; panics if cellIndex is out of range
+func (g Grid) Cell(cellIndex int) Cell {
+	if cellIndex < 0 || cellIndex >= g.numCells() {
+		panic(fmt.Sprintf("cell index %v is outside a grid with %v cells", cellIndex, g.numCells()))
+	}
 
-	// flat slice of cells, row-by-row; may change representation later
-	// invariant: len(cells) == baseSize ^ 4
-	cells []*Cell // references to cells so they can be mutated; none of these should ever be nil
+	return Cell{
+		grid:  g,
+		index: cellIndex,
+	}
 }
 
-func EmptyGrid(baseSize int) Grid {
-	grid := Grid{
-		baseSize: baseSize,
+// views of every cell in g, row by row
+func (g Grid) Cells() []Cell {
+	cells := make([]Cell, g.numCells())
+	for i := range cells {
+		cells[i] = g.Cell(i)
 	}
 
-	// example: a 9x9 Sudoku has baseSize 3: each side of the grid is 3*3 = 9 cells
-	// the total grid is 9*9 = 81 = 3^4 cells
-	// use repeated multiplication instead of math.Pow() to avoid converting to/from float64
-	grid.cells = make([]*Cell, baseSize*baseSize*baseSize*baseSize)
+	return cells
+}
+
+// the values in each row, from top to bottom, with 0 for empty cells
+// the returned slices are copies, so modifying them doesn't affect g
+func (g Grid) Rows() [][]int {
+	return g.houseValues(g.rows())
+}
+
+// the values in each column, from left to right, with 0 for empty cells
+// the returned slices are copies, so modifying them doesn't affect g
+func (g Grid) Cols() [][]int {
+	return g.houseValues(g.cols())
+}
+
+// the values in each box, numbered as in BoxOf(), with each box's values row by row, and 0 for empty cells
+// the returned slices are copies, so modifying them doesn't affect g
+func (g Grid) Boxes() [][]int {
+	return g.houseValues(g.boxes())
+}
 
-	return grid
+// the values in every house: all rows, then all columns, then all boxes (in the same order as Rows(), Cols(), and Boxes())
+func (g Grid) Houses() [][]int {
+	return g.houseValues(g.houseIndexes())
 }
 
-func (g *Grid) sideLength() int {
-	return g.baseSize * g.baseSize
+func (g Grid) houseValues(houses [][]int) [][]int {
+	return lo.Map(houses, func(house []int, _ int) []int {
+		return lo.Map(house, func(cellIndex int, _ int) int {
+			return g.valueAt(cellIndex)
+		})
+	})
 }
 
 // each house in the grid must have all elements in the range from 1 to maxElement(), inclusive
 // this is the same calculation as sideLength(), but split into a separate method for clarity
-func (g *Grid) maxElement() int {
-	return g.baseSize * g.baseSize
+func (g Grid) maxElement() int {
+	return g.size
+}
+
+// an empty grid with the same size and box shape as g
+func (g Grid) emptied() Grid {
+	g.values = strings.Repeat("\x00", g.numCells())
+	return g
+}
+
+// total number of cells in the grid
+func (g Grid) numCells() int {
+	return len(g.values)
+}
+
+// the value of the cell at cellIndex, or 0 if it's empty
+func (g Grid) valueAt(cellIndex int) int {
+	return int(g.values[cellIndex])
+}
+
+func (g Grid) isEmptyAt(cellIndex int) bool {
+	return g.values[cellIndex] == 0
+}
+
+// sets the value of the cell at cellIndex, with 0 emptying the cell
+// doesn't check that value is in range; callers are responsible for that
+// strings can't be modified in place, so this copies every value; code that changes many cells should use a gridBuffer instead
+func (g *Grid) setValueAt(cellIndex int, value int) {
+	buffer := g.buffer()
+	buffer.setValueAt(cellIndex, value)
+	*g = buffer.grid()
+}
+
+// a mutable copy of a grid's values, for code that changes many cells one at a time, like the parsers and solvers
+// Grid keeps its values in a string so that it's comparable, which means every change to a Grid copies all of its values;
+// changing a gridBuffer doesn't, and grid() converts it back to a Grid with a single copy once the changes are done
+type gridBuffer struct {
+	// the grid the buffer was made from, for its size and box shape; its values are out of date once the buffer is changed
+	geometry Grid
+
+	// one byte per cell, as in Grid.values
+	values []byte
+}
+
+// a gridBuffer holding a copy of g's values
+func (g Grid) buffer() gridBuffer {
+	return gridBuffer{
+		geometry: g,
+		values:   []byte(g.values),
+	}
+}
+
+// a Grid with the buffer's current values; later changes to the buffer don't affect it
+func (b gridBuffer) grid() Grid {
+	g := b.geometry
+	g.values = string(b.values)
+	return g
+}
+
+// returns a deep copy of b; changes to the copy don't affect b, and vice versa
+func (b gridBuffer) clone() gridBuffer {
+	return gridBuffer{
+		geometry: b.geometry,
+		values:   slices.Clone(b.values),
+	}
+}
+
+func (b gridBuffer) valueAt(cellIndex int) int {
+	return int(b.values[cellIndex])
+}
+
+func (b gridBuffer) isEmptyAt(cellIndex int) bool {
+	return b.values[cellIndex] == 0
+}
+
+// sets the value of the cell at cellIndex, with 0 emptying the cell; like Grid.setValueAt(), doesn't check that value is in range
+func (b gridBuffer) setValueAt(cellIndex int, value int) {
+	b.values[cellIndex] = byte(value)
+}
+
+func (b gridBuffer) numCells() int {
+	return len(b.values)
+}
+
+func (b gridBuffer) sideLength() int {
+	return b.geometry.sideLength()
+}
+
+func (b gridBuffer) maxElement() int {
+	return b.geometry.maxElement()
+}
+
+func (b gridBuffer) filledCellCount() int {
+	return b.numCells() - bytes.Count(b.values, []byte{0})
+}
+
+// same as Grid.IsValidSolution(), but only converts the buffer to a Grid when every cell is filled
+func (b gridBuffer) isValidSolution() bool {
+	return b.filledCellCount() == b.numCells() && b.grid().IsValidSolution()
 }
 
-func (g *Grid) cellAt(row int, col int) *Cell {
+// index of the cell at (row, col); panics if either is out of range
+func (g Grid) cellIndex(row int, col int) int {
+	if row < 0 || row >= g.sideLength() || col < 0 || col >= g.sideLength() {
+		panic(fmt.Sprintf("(%v, %v) is outside a grid with side length %v", row, col, g.sideLength()))
+	}
+
 	// could inline these into a single line, but gofmt's arithmetic formatting makes the inlined version less clear
 	rowBaseIndex := row * g.sideLength()
-	index := rowBaseIndex + col
-	return g.cells[index]
+	return rowBaseIndex + col
 }
 
-func (g *Grid) rows() [][]*Cell {
-	rows := [][]*Cell{}
+// indexes of all cells that share a row, column, or box (if g has boxes) with the cell at cellIndex, in increasing order
+// a cell isn't its own peer
+func (g Grid) peerIndexes(cellIndex int) []int {
+	peerIndexes := []int{}
+
+	row, col, box := g.RowOf(cellIndex), g.ColOf(cellIndex), g.BoxOf(cellIndex)
+	for i := range g.numCells() {
+		if i == cellIndex {
+			continue
+		}
+
+		if g.RowOf(i) == row || g.ColOf(i) == col || (g.HasBoxes() && g.BoxOf(i) == box) {
+			peerIndexes = append(peerIndexes, i)
+		}
+	}
+
+	return peerIndexes
+}
+
+// indexes of the cells in each row, from top to bottom
+func (g Grid) rows() [][]int {
+	rows := [][]int{}
 
 	for r := 0; r < g.sideLength(); r++ {
-		row := []*Cell{}
-		// rowBaseIndex := r * g.sideLength() // could inline this, but gofmt's arithmetic formatting makes the inlined version less clear
+		row := []int{}
 		for c := 0; c < g.sideLength(); c++ {
-			// idx := rowBaseIndex + c // could inline this, but gofmt's arithmetic formatting makes the inlined version less clear
-			// row[c] = g.cells[idx]
-			// row[c] = g.cellAt(r, c)
-			row = append(row, g.cellAt(r, c))
+			row = append(row, g.cellIndex(r, c))
 		}
 		rows = append(rows, row)
 	}
@@ -197,49 +488,43 @@ func (g *Grid) rows() [][]*Cell {
 	return rows
 }
 
-func (g *Grid) cols() [][]*Cell {
-	cols := [][]*Cell{}
+// indexes of the cells in each column, from left to right
+func (g Grid) cols() [][]int {
+	cols := [][]int{}
 
 	for c := 0; c < g.sideLength(); c++ {
-		col := []*Cell{}
-
+		col := []int{}
 		for r := 0; r < g.sideLength(); r++ {
-			// idx := r*g.sideLength() + c
-			// col[r] = g.cells[idx]
-			// col[r] = g.cellAt(r, c)
-			col = append(col, g.cellAt(r, c))
+			col = append(col, g.cellIndex(r, c))
 		}
-
 		cols = append(cols, col)
 	}
 
 	return cols
 }
 
-func (g *Grid) boxes() [][]*Cell {
-	boxes := [][]*Cell{}
+// indexes of the cells in each box, with boxes numbered as in BoxOf() and each box's cells listed row by row
+// Latin squares have no boxes, so this is empty for them
+func (g Grid) boxes() [][]int {
+	boxes := [][]int{}
 
 	// boxRow and boxCol are the row/column of the boxes within the overall grid;
-	// for example, for a 9x9 sudoku (baseSize == 3), the grid has boxes in a 3x3 arrangement
+	// for example, for a 9x9 sudoku (3x3 boxes), the grid has boxes in a 3x3 arrangement
 	// so the upper-left box has boxRow 0 and boxCol 0
 	// the center-left box has boxRow 1 and boxCol 0
 	// the middle box has boxRow 1 and boxCol 1
 	// (and so on)
+	// for non-square boxes, the arrangement is transposed from the boxes' shape:
+	// a 6x6 sudoku has 3x2 boxes (3 columns wide, 2 rows tall), arranged in 2 columns and 3 rows
 
-	// TODO - explain calculations
-	// TODO - is there a way to calculate each cell's row and column independently, then use g.cellAt(), to make this simpler?
-
-	for boxRow := 0; boxRow < g.baseSize; boxRow++ {
-		for boxCol := 0; boxCol < g.baseSize; boxCol++ {
-			box := []*Cell{}
-
-			boxBaseIndex := (boxRow * g.baseSize * g.sideLength()) + (boxCol * g.baseSize)
+	for boxRow := 0; boxRow < g.boxWidth; boxRow++ {
+		for boxCol := 0; boxCol < g.boxHeight; boxCol++ {
+			box := []int{}
 
 			// r and c are coordinates relative to the inside of the box
-			for r := 0; r < g.baseSize; r++ {
-				for c := 0; c < g.baseSize; c++ {
-					index := boxBaseIndex + r*g.sideLength() + c
-					box = append(box, g.cells[index])
+			for r := 0; r < g.boxHeight; r++ {
+				for c := 0; c < g.boxWidth; c++ {
+					box = append(box, g.cellIndex(boxRow*g.boxHeight+r, boxCol*g.boxWidth+c))
 				}
 			}
 
@@ -250,33 +535,28 @@ func (g *Grid) boxes() [][]*Cell {
 	return boxes
 }
 
-func (g *Grid) IsCompletelyFilled() bool {
-	for _, cell := range g.cells {
-		if cell.isEmpty() {
-			return false
-		}
-	}
+// indexes of the cells in each house: all rows, then all columns, then all boxes (if g has boxes)
+func (g Grid) houseIndexes() [][]int {
+	return slices.Concat(g.rows(), g.cols(), g.boxes())
+}
 
-	return true
+func (g Grid) IsCompletelyFilled() bool {
+	return g.filledCellCount() == g.numCells()
+}
+
+// number of cells that have a value
+func (g Grid) filledCellCount() int {
+	return g.numCells() - strings.Count(g.values, "\x00")
 }
 
 // checks if the house contains all elements in the range from 1 to maxElement, inclusive
-func isHouseValid(house []*Cell, maxElement int) bool {
+// empty cells are represented by 0, so a house with any empty cells is never valid
+func isHouseValid(house []int, maxElement int) bool {
 	if len(house) != maxElement {
 		return false
 	}
 
-	for _, cell := range house {
-		if cell.isEmpty() {
-			return false
-		}
-	}
-
-	// all cells in the house are non-nil
-
-	elementsInHouse := lo.Map(house, func(cell *Cell, _ int) int {
-		return *cell.value
-	})
+	elementsInHouse := slices.Clone(house)
 	slices.Sort(elementsInHouse)
 
 	allElements := lo.RangeFrom(1, maxElement)
@@ -284,30 +564,18 @@ func isHouseValid(house []*Cell, maxElement int) bool {
 	return slices.Equal(elementsInHouse, allElements)
 }
 
-// checks if each row, column, and box has exactly one of each digit/element
+// checks if each row, column, and box (if the grid has boxes) has exactly one of each digit/element
 // only checks completely filled-out grids; if a grid has any empty cells, this returns false
-// does *not* check if a grid matches a specific challenge (i.e. whether it matches the givens from the challenge)
-func (g *Grid) IsValidSolution() bool {
+// does *not* check if a grid matches a specific challenge (i.e. whether it matches the givens from the challenge); use IsSolutionOf() for that
+func (g Grid) IsValidSolution() bool {
 	if !g.IsCompletelyFilled() {
 		return false
 	}
 
-	// all cells have values (are non-nil)
-
-	for _, row := range g.rows() {
-		if !isHouseValid(row, g.maxElement()) {
-			return false
-		}
-	}
-
-	for _, col := range g.cols() {
-		if !isHouseValid(col, g.maxElement()) {
-			return false
-		}
-	}
+	// all cells have values
 
-	for _, box := range g.boxes() {
-		if !isHouseValid(box, g.maxElement()) {
+	for _, house := range g.Houses() {
+		if !isHouseValid(house, g.maxElement()) {
 			return false
 		}
 	}
@@ -315,10 +583,10 @@ func (g *Grid) IsValidSolution() bool {
 	return true
 }
 
-func (g *Grid) String() string {
+func (g Grid) String() string {
 	var b strings.Builder
 
-	for _, cell := range g.cells {
+	for _, cell := range g.Cells() {
 		b.WriteString(cell.String())
 	}
 
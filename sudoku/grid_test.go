@@ -0,0 +1,343 @@
+package sudoku_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridAccessors(t *testing.T) {
+	grid := sudoku.ParseSingleGrid("1......4..2..3..")
+
+	t.Run("Size", func(t *testing.T) {
+		baseSize, ok := grid.BaseSize()
+		assert.True(t, ok)
+		assert.EqualValues(t, 2, baseSize)
+		assert.EqualValues(t, 4, grid.SideLength())
+	})
+
+	t.Run("Getting values", func(t *testing.T) {
+		assert.EqualValues(t, 1, grid.Get(0, 0))
+		assert.EqualValues(t, 0, grid.Get(0, 1))
+		assert.EqualValues(t, 4, grid.Get(1, 3))
+		assert.EqualValues(t, 2, grid.Get(2, 2))
+		assert.EqualValues(t, 3, grid.Get(3, 1))
+
+		assert.Panics(t, func() { grid.Get(4, 0) })
+		assert.Panics(t, func() { grid.Get(0, -1) })
+	})
+
+	t.Run("Houses", func(t *testing.T) {
+		assert.EqualValues(t, [][]int{{1, 0, 0, 0}, {0, 0, 0, 4}, {0, 0, 2, 0}, {0, 3, 0, 0}}, grid.Rows())
+		assert.EqualValues(t, [][]int{{1, 0, 0, 0}, {0, 0, 0, 3}, {0, 0, 2, 0}, {0, 4, 0, 0}}, grid.Cols())
+		assert.EqualValues(t, [][]int{{1, 0, 0, 0}, {0, 0, 0, 4}, {0, 0, 0, 3}, {2, 0, 0, 0}}, grid.Boxes())
+
+		houses := grid.Houses()
+		assert.Len(t, houses, 12)
+		assert.EqualValues(t, grid.Rows()[0], houses[0])
+		assert.EqualValues(t, grid.Cols()[0], houses[4])
+		assert.EqualValues(t, grid.Boxes()[0], houses[8])
+
+		// the returned houses are copies
+		grid.Rows()[0][0] = 4
+		assert.EqualValues(t, 1, grid.Get(0, 0))
+	})
+
+	t.Run("Cell coordinates", func(t *testing.T) {
+		nineByNine := sudoku.EmptyGrid(3)
+
+		// the center cell of the middle-right box
+		cellIndex := 4*9 + 7
+		assert.EqualValues(t, 4, nineByNine.RowOf(cellIndex))
+		assert.EqualValues(t, 7, nineByNine.ColOf(cellIndex))
+		assert.EqualValues(t, 5, nineByNine.BoxOf(cellIndex))
+
+		assert.EqualValues(t, 0, nineByNine.BoxOf(0))
+		assert.EqualValues(t, 8, nineByNine.BoxOf(80))
+	})
+}
+
+func TestGridModification(t *testing.T) {
+	t.Run("Setting and clearing values", func(t *testing.T) {
+		grid := sudoku.EmptyGrid(2)
+
+		grid.Set(1, 2, 3)
+		assert.EqualValues(t, 3, grid.Get(1, 2))
+		assert.EqualValues(t, "......3.........", grid.String())
+
+		grid.Set(1, 2, 4)
+		assert.EqualValues(t, 4, grid.Get(1, 2))
+
+		grid.Clear(1, 2)
+		assert.EqualValues(t, 0, grid.Get(1, 2))
+
+		assert.Panics(t, func() { grid.Set(0, 0, 5) })
+		assert.Panics(t, func() { grid.Set(0, 0, 0) })
+		assert.Panics(t, func() { grid.Set(0, 4, 1) })
+	})
+
+	t.Run("Clones are independent of the original", func(t *testing.T) {
+		original := sudoku.ParseSingleGrid("1......4..2..3..")
+		clone := original.Clone()
+		assert.True(t, clone.Equal(original))
+
+		clone.Set(0, 1, 2)
+		clone.Clear(0, 0)
+		assert.EqualValues(t, 1, original.Get(0, 0))
+		assert.EqualValues(t, 0, original.Get(0, 1))
+		assert.False(t, clone.Equal(original))
+	})
+
+	t.Run("Equality", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("1......4..2..3..")
+		assert.True(t, grid.Equal(sudoku.ParseSingleGrid("1......4..2..3..")))
+		assert.False(t, grid.Equal(sudoku.ParseSingleGrid("1......4..2.....")))
+		assert.False(t, grid.Equal(sudoku.EmptyGrid(3)))
+	})
+
+	t.Run("Copies are independent of the original", func(t *testing.T) {
+		original := sudoku.ParseSingleGrid("1......4..2..3..")
+		copied := original
+
+		copied.Set(0, 1, 2)
+		assert.EqualValues(t, 0, original.Get(0, 1))
+		assert.EqualValues(t, "1......4..2..3..", original.String())
+	})
+
+	t.Run("Grids can be used as map keys", func(t *testing.T) {
+		seen := map[sudoku.Grid]int{}
+		seen[sudoku.ParseSingleGrid("1......4..2..3..")]++
+		seen[sudoku.ParseSingleGrid("1......4..2..3..")]++
+		seen[sudoku.EmptyGrid(2)]++
+
+		assert.Len(t, seen, 2)
+		assert.EqualValues(t, 2, seen[sudoku.ParseSingleGrid("1......4..2..3..")])
+	})
+}
+
+func TestCells(t *testing.T) {
+	grid := sudoku.ParseSingleGrid("1......4..2..3..")
+
+	t.Run("Cell views", func(t *testing.T) {
+		cell := grid.Cell(7)
+		assert.EqualValues(t, 7, cell.Index())
+		assert.EqualValues(t, 1, cell.Row())
+		assert.EqualValues(t, 3, cell.Col())
+		assert.EqualValues(t, 1, cell.Box())
+		assert.EqualValues(t, 4, cell.Value())
+		assert.False(t, cell.IsEmpty())
+		assert.EqualValues(t, "4", cell.String())
+
+		assert.True(t, grid.Cell(1).IsEmpty())
+		assert.Len(t, grid.Cells(), 16)
+
+		assert.Panics(t, func() { grid.Cell(16) })
+	})
+
+	t.Run("Cells are snapshots of their grid", func(t *testing.T) {
+		modified := grid
+		cell := modified.Cell(1)
+
+		modified.Set(0, 1, 3)
+		assert.True(t, cell.IsEmpty())
+		assert.EqualValues(t, 3, modified.Cell(1).Value())
+	})
+
+	t.Run("Peers", func(t *testing.T) {
+		peerIndexes := []int{}
+		for _, peer := range grid.Cell(0).AllPeers() {
+			peerIndexes = append(peerIndexes, peer.Index())
+		}
+
+		// the rest of the first row, the rest of the first column, and the rest of the top-left box
+		assert.EqualValues(t, []int{1, 2, 3, 4, 5, 8, 12}, peerIndexes)
+
+		// 9x9 cells have 8 peers in each house, with 4 shared between the box and the row/column
+		assert.Len(t, sudoku.EmptyGrid(3).Cell(40).AllPeers(), 20)
+	})
+}
+
+func TestNewGridFromValues(t *testing.T) {
+	t.Run("Creating a grid", func(t *testing.T) {
+		grid, err := sudoku.NewGridFromValues([][]int{
+			{1, 0, 0, 0},
+			{0, 0, 0, 4},
+			{0, 0, 2, 0},
+			{0, 3, 0, 0},
+		})
+		assert.NoError(t, err)
+		assert.True(t, grid.Equal(sudoku.ParseSingleGrid("1......4..2..3..")))
+	})
+
+	t.Run("Invalid values return an error", func(t *testing.T) {
+		invalidValues := map[string][][]int{
+			"no rows":                               {},
+			"side length can't be split into boxes": {{1, 2, 3}, {0, 0, 0}, {0, 0, 0}},
+			"ragged rows":                           {{1, 0, 0, 0}, {0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}},
+			"value too large":                       {{5, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}},
+			"negative value":                        {{-1, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}},
+		}
+
+		for name, values := range invalidValues {
+			_, err := sudoku.NewGridFromValues(values)
+			assert.Error(t, err, name)
+		}
+	})
+
+	t.Run("Creating a grid with a given box shape", func(t *testing.T) {
+		values := make([][]int, 6)
+		for i := range values {
+			values[i] = make([]int, 6)
+		}
+		values[0][0] = 6
+
+		grid, err := sudoku.NewGridFromValuesWithBoxes(values, 2, 3)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, grid.BoxWidth())
+		assert.EqualValues(t, 3, grid.BoxHeight())
+		assert.EqualValues(t, 6, grid.Get(0, 0))
+
+		expected := sudoku.EmptyGridWithBoxes(2, 3)
+		expected.Set(0, 0, 6)
+		assert.True(t, grid.Equal(expected))
+
+		_, err = sudoku.NewGridFromValuesWithBoxes(values, 3, 3)
+		assert.Error(t, err)
+
+		_, err = sudoku.NewGridFromValuesWithBoxes(values, 6, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("Creating a Latin square", func(t *testing.T) {
+		values := [][]int{
+			{1, 2, 3},
+			{2, 3, 1},
+			{3, 1, 0},
+		}
+
+		grid, err := sudoku.NewGridFromValuesWithBoxes(values, 0, 0)
+		assert.NoError(t, err)
+		assert.False(t, grid.HasBoxes())
+		assert.True(t, grid.Equal(sudoku.ParseLatinSquare("12323131.")))
+	})
+}
+
+func TestNonSquareBoxes(t *testing.T) {
+	// 6x6 grid with 3x2 boxes:
+	// 123|456
+	// 456|123
+	// ---+---
+	// 231|564
+	// 564|231
+	// ---+---
+	// 312|645
+	// 645|312
+	solution := sudoku.ParseSingleGrid("123456456123231564564231312645645312")
+
+	t.Run("Geometry", func(t *testing.T) {
+		assert.EqualValues(t, 3, solution.BoxWidth())
+		assert.EqualValues(t, 2, solution.BoxHeight())
+		assert.EqualValues(t, 6, solution.SideLength())
+		_, ok := solution.BaseSize()
+		assert.False(t, ok)
+
+		assert.EqualValues(t, 0, solution.BoxOf(0))
+		assert.EqualValues(t, 1, solution.BoxOf(3))
+		assert.EqualValues(t, 0, solution.BoxOf(8))
+		assert.EqualValues(t, 2, solution.BoxOf(12))
+		assert.EqualValues(t, 5, solution.BoxOf(35))
+
+		assert.EqualValues(t, []int{1, 2, 3, 4, 5, 6}, solution.Boxes()[0])
+		assert.EqualValues(t, []int{4, 5, 6, 1, 2, 3}, solution.Boxes()[1])
+		assert.EqualValues(t, []int{2, 3, 1, 5, 6, 4}, solution.Boxes()[2])
+		assert.Len(t, solution.Houses(), 18)
+
+		// 5 others in the row, 5 in the column, and 2 in the box that aren't in the same row or column
+		assert.Len(t, solution.Cell(0).AllPeers(), 12)
+	})
+
+	t.Run("Checking solutions", func(t *testing.T) {
+		assert.True(t, solution.IsValidSolution())
+
+		// rows and columns are fine, but the boxes aren't: the top-left box has two 1s
+		assert.False(t, sudoku.ParseSingleGrid("123456561234345612612345234561456123").IsValidSolution())
+	})
+
+	t.Run("Creating grids", func(t *testing.T) {
+		assert.EqualValues(t, 36, len(sudoku.EmptyGridWithBoxes(3, 2).String()))
+		assert.True(t, sudoku.EmptyGridWithBoxes(3, 2).Equal(sudoku.ParseSingleGrid(strings.Repeat(".", 36))))
+		assert.False(t, sudoku.EmptyGridWithBoxes(3, 2).Equal(sudoku.EmptyGridWithBoxes(2, 3)))
+
+		fromValues, err := sudoku.NewGridFromValues(solution.Rows())
+		assert.NoError(t, err)
+		assert.True(t, fromValues.Equal(solution))
+
+		boxShapes := map[int][2]int{4: {2, 2}, 6: {3, 2}, 8: {4, 2}, 9: {3, 3}, 10: {5, 2}, 12: {4, 3}}
+		for sideLength, boxShape := range boxShapes {
+			values := make([][]int, sideLength)
+			for i := range values {
+				values[i] = make([]int, sideLength)
+			}
+
+			grid, err := sudoku.NewGridFromValues(values)
+			assert.NoError(t, err)
+			assert.EqualValues(t, boxShape[0], grid.BoxWidth(), sideLength)
+			assert.EqualValues(t, boxShape[1], grid.BoxHeight(), sideLength)
+		}
+	})
+
+	t.Run("Values above 9 are printed as letters", func(t *testing.T) {
+		grid := sudoku.EmptyGridWithBoxes(4, 3)
+		grid.Set(0, 0, 10)
+		grid.Set(0, 1, 12)
+		grid.Set(11, 11, 9)
+
+		str := grid.String()
+		assert.EqualValues(t, "AC", str[:2])
+		assert.EqualValues(t, "9", str[143:])
+		assert.True(t, sudoku.ParseSingleGrid(str).Equal(grid))
+	})
+}
+
+func TestLatinSquares(t *testing.T) {
+	square := sudoku.ParseLatinSquare("1234234134124123")
+
+	t.Run("Geometry", func(t *testing.T) {
+		assert.False(t, square.HasBoxes())
+		assert.True(t, sudoku.EmptyGrid(2).HasBoxes())
+		assert.EqualValues(t, 4, square.SideLength())
+		assert.EqualValues(t, 0, square.BoxWidth())
+		_, ok := square.BaseSize()
+		assert.False(t, ok)
+
+		assert.Empty(t, square.Boxes())
+		assert.Len(t, square.Houses(), 8)
+		assert.EqualValues(t, -1, square.BoxOf(0))
+		assert.EqualValues(t, -1, square.Cell(5).Box())
+
+		// 3 others in the row, and 3 in the column
+		assert.Len(t, square.Cell(0).AllPeers(), 6)
+	})
+
+	t.Run("Checking solutions", func(t *testing.T) {
+		assert.True(t, square.IsValidSolution())
+
+		// a valid Latin square, but not a valid Sudoku solution, since the top-left box has two 2s
+		assert.False(t, sudoku.ParseSingleGrid("1234234134124123").IsValidSolution())
+	})
+
+	t.Run("Latin squares and Sudoku grids are never equal", func(t *testing.T) {
+		assert.False(t, sudoku.EmptyLatinSquare(4).Equal(sudoku.EmptyGrid(2)))
+		assert.True(t, sudoku.EmptyLatinSquare(4).Equal(sudoku.ParseLatinSquare("................")))
+		assert.EqualValues(t, square.String(), sudoku.ParseLatinSquare(square.String()).String())
+	})
+
+	t.Run("Latin squares can have any side length", func(t *testing.T) {
+		fiveByFive := sudoku.ParseLatinSquare("1234523451345124512351234")
+		assert.True(t, fiveByFive.IsValidSolution())
+
+		assert.Panics(t, func() { sudoku.ParseLatinSquare("12345") })
+	})
+}
@@ -0,0 +1,230 @@
+package sudoku
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// a way of picking what to branch on during backtracking search
+// every heuristic splits the search into branches that are exhaustive and don't overlap, so solution counts are the same whichever one is used
+type Heuristic int
+
+const (
+	FirstCandidate         Heuristic = iota // the first cell with at least 2 possibilities
+	RandomCandidate                         // a random cell with at least 2 possibilities, always trying its values in random order
+	MinimumRemainingValues                  // the cell with the fewest possibilities, so there are as few branches as possible
+	MaxDegree                               // the cell with the most empty peers, breaking ties by fewest possibilities
+
+	// the cell with the fewest possibilities, or a value with the fewest possible positions in one of its houses,
+	// branching on each of those positions, whichever leads to fewer branches
+	// a value with only one position in a house is a hidden single, so this places hidden singles without any real branching,
+	// and a value with no positions left in a house is detected as a dead end straight away
+	HouseBranching
+)
+
+var allHeuristics = []Heuristic{
+	FirstCandidate,
+	RandomCandidate,
+	MinimumRemainingValues,
+	MaxDegree,
+	HouseBranching,
+}
+
+func (h Heuristic) String() string {
+	switch h {
+	case FirstCandidate:
+		return "First candidate"
+	case RandomCandidate:
+		return "Random candidate"
+	case MinimumRemainingValues:
+		return "Minimum remaining values"
+	case MaxDegree:
+		return "Max degree"
+	case HouseBranching:
+		return "House branching"
+	default:
+		return "unknown"
+	}
+}
+
+// the order to try possible values in, when branching on a cell during backtracking search
+type ValueOrder int
+
+const (
+	IncreasingValues       ValueOrder = iota
+	LeastConstrainingValue            // values that rule out the fewest possibilities from the cell's peers first
+	RandomValues                      // a random order, using the solver's seed
+)
+
+var allValueOrders = []ValueOrder{
+	IncreasingValues,
+	LeastConstrainingValue,
+	RandomValues,
+}
+
+func (o ValueOrder) String() string {
+	switch o {
+	case IncreasingValues:
+		return "Increasing values"
+	case LeastConstrainingValue:
+		return "Least constraining value"
+	case RandomValues:
+		return "Random values"
+	default:
+		return "unknown"
+	}
+}
+
+// a single value assignment to try as one branch of a backtracking search
+type searchBranch struct {
+	cellIndex int
+	value     int
+}
+
+// picks what to branch on next, returning every branch that needs to be searched, in the order they should be tried
+// puzzle must be incomplete, with every empty cell having at least 2 possibilities (so applyBasicStrategies() has been run)
+// returns no branches if the heuristic finds that the puzzle is a dead end
+func chooseBranches(puzzle *Puzzle, heuristic Heuristic, valueOrder ValueOrder, rng *rand.Rand) []searchBranch {
+	// cells with fewer than 2 possibilities have already been filled in
+	searchCandidates := []int{}
+	for i, cellPossibilities := range puzzle.possibleValues {
+		if cellPossibilities.Size() >= 2 {
+			searchCandidates = append(searchCandidates, i)
+		}
+	}
+
+	if len(searchCandidates) == 0 {
+		panic("couldn't find a cell with at least 2 possibilities, even though there should be one!")
+	}
+
+	var searchCellIndex int
+	switch heuristic {
+	case FirstCandidate:
+		searchCellIndex = searchCandidates[0]
+	case RandomCandidate:
+		searchCellIndex = searchCandidates[rng.IntN(len(searchCandidates))]
+		valueOrder = RandomValues
+	case MinimumRemainingValues:
+		searchCellIndex = findMinimumRemainingValuesCell(puzzle, searchCandidates)
+	case MaxDegree:
+		searchCellIndex = findMaxDegreeCell(puzzle, searchCandidates)
+	case HouseBranching:
+		searchCellIndex = findMinimumRemainingValuesCell(puzzle, searchCandidates)
+
+		houseBranches, ok := findHouseBranches(puzzle)
+		if ok && len(houseBranches) < puzzle.possibleValues[searchCellIndex].Size() {
+			return houseBranches
+		}
+	default:
+		panic("unknown search heuristic")
+	}
+
+	values := orderValues(puzzle, searchCellIndex, valueOrder, rng)
+
+	branches := make([]searchBranch, 0, len(values))
+	for _, value := range values {
+		branches = append(branches, searchBranch{
+			cellIndex: searchCellIndex,
+			value:     value,
+		})
+	}
+
+	return branches
+}
+
+// the candidate cell with the fewest possibilities; ties go to the earliest cell
+func findMinimumRemainingValuesCell(puzzle *Puzzle, searchCandidates []int) int {
+	return slices.MinFunc(searchCandidates, func(a int, b int) int {
+		return puzzle.possibleValues[a].Size() - puzzle.possibleValues[b].Size()
+	})
+}
+
+// the candidate cell with the most empty peers, which constrains the rest of the puzzle the most
+// ties go to the cell with fewer possibilities, then to the earliest cell
+func findMaxDegreeCell(puzzle *Puzzle, searchCandidates []int) int {
+	emptyPeerCount := func(cellIndex int) int {
+		count := 0
+		for _, peerIndex := range puzzle.peerIndexes[cellIndex] {
+			if puzzle.isCellEmpty(peerIndex) {
+				count++
+			}
+		}
+		return count
+	}
+
+	return slices.MinFunc(searchCandidates, func(a int, b int) int {
+		if degreeA, degreeB := emptyPeerCount(a), emptyPeerCount(b); degreeA != degreeB {
+			return degreeB - degreeA
+		}
+
+		return puzzle.possibleValues[a].Size() - puzzle.possibleValues[b].Size()
+	})
+}
+
+// finds the house and unplaced value with the fewest possible positions for that value,
+// returning a branch for each position (or no branches if there's a value with no possible positions)
+// returns false if every value has been placed in every house
+func findHouseBranches(puzzle *Puzzle) ([]searchBranch, bool) {
+	var bestPositions []int
+	bestValue := 0
+	found := false
+
+	for _, house := range puzzle.houseIndexes {
+		for value := 1; value <= puzzle.underlyingGrid.maxElement(); value++ {
+			if puzzle.isValuePlacedInHouse(house, value) {
+				continue
+			}
+
+			positions := puzzle.cellsWithPossibility(house, value)
+			if !found || len(positions) < len(bestPositions) {
+				bestPositions = positions
+				bestValue = value
+				found = true
+			}
+		}
+	}
+
+	branches := make([]searchBranch, 0, len(bestPositions))
+	for _, cellIndex := range bestPositions {
+		branches = append(branches, searchBranch{
+			cellIndex: cellIndex,
+			value:     bestValue,
+		})
+	}
+
+	return branches, found
+}
+
+// the possibilities for the cell at cellIndex, in the order given by valueOrder
+func orderValues(puzzle *Puzzle, cellIndex int, valueOrder ValueOrder, rng *rand.Rand) []int {
+	values := puzzle.sortedPossibilities(cellIndex)
+
+	switch valueOrder {
+	case IncreasingValues:
+		// already sorted
+	case LeastConstrainingValue:
+		// how many possibilities assigning value would rule out from the cell's empty peers
+		eliminationCount := func(value int) int {
+			count := 0
+			for _, peerIndex := range puzzle.peerIndexes[cellIndex] {
+				if puzzle.isCellEmpty(peerIndex) && puzzle.possibleValues[peerIndex].Has(value) {
+					count++
+				}
+			}
+			return count
+		}
+
+		// stable, so ties stay in increasing order
+		slices.SortStableFunc(values, func(a int, b int) int {
+			return eliminationCount(a) - eliminationCount(b)
+		})
+	case RandomValues:
+		rng.Shuffle(len(values), func(i, j int) {
+			values[i], values[j] = values[j], values[i]
+		})
+	default:
+		panic("unknown value order")
+	}
+
+	return values
+}
@@ -0,0 +1,114 @@
+package sudoku_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+var allHeuristics = []sudoku.Heuristic{
+	sudoku.FirstCandidate,
+	sudoku.RandomCandidate,
+	sudoku.MinimumRemainingValues,
+	sudoku.MaxDegree,
+	sudoku.HouseBranching,
+}
+
+var allValueOrders = []sudoku.ValueOrder{
+	sudoku.IncreasingValues,
+	sudoku.LeastConstrainingValue,
+	sudoku.RandomValues,
+}
+
+// total number of search nodes the backtracking solver needs to solve every grid
+func totalNodes(t *testing.T, grids []sudoku.Grid, options sudoku.SolverOptions) int {
+	t.Helper()
+
+	nodes := 0
+	for _, grid := range grids {
+		result, err := sudoku.BacktrackingSolver{Options: options}.Solve(context.Background(), grid)
+		assert.NoError(t, err)
+		assert.True(t, result.Solutions[0].IsValidSolution())
+
+		nodes += result.Stats.Nodes
+	}
+
+	return nodes
+}
+
+func TestHeuristics(t *testing.T) {
+	for _, heuristic := range allHeuristics {
+		for _, valueOrder := range allValueOrders {
+			t.Run(fmt.Sprintf("%v, %v", heuristic, valueOrder), func(t *testing.T) {
+				options := sudoku.SolverOptions{
+					Heuristic:  heuristic,
+					ValueOrder: valueOrder,
+					Seed:       1,
+				}
+
+				t.Run("Solving easy 9x9 challenges", func(t *testing.T) {
+					nodes := totalNodes(t, loadExampleGrids(t, "9x9", "easy50.txt")[:5], options)
+					assert.Positive(t, nodes)
+				})
+
+				t.Run("Counting solutions", func(t *testing.T) {
+					options.MaxSolutions = 1000
+
+					result, err := sudoku.BacktrackingSolver{Options: options}.Solve(context.Background(), sudoku.EmptyGrid(2))
+					assert.NoError(t, err)
+					assert.Len(t, result.Solutions, 288)
+
+					// every solution is different
+					distinctSolutions := map[string]bool{}
+					for _, solution := range result.Solutions {
+						distinctSolutions[solution.String()] = true
+					}
+					assert.Len(t, distinctSolutions, 288)
+
+					_, err = sudoku.BacktrackingSolver{Options: options}.Solve(context.Background(), sudoku.ParseSingleGrid("11.............."))
+					assert.ErrorIs(t, err, sudoku.ErrNoSolution)
+				})
+			})
+		}
+	}
+
+	t.Run("Smarter heuristics explore fewer nodes on hard challenges", func(t *testing.T) {
+		challenges := loadExampleGrids(t, "9x9", "hardest.txt")
+
+		firstCandidateNodes := totalNodes(t, challenges, sudoku.SolverOptions{Heuristic: sudoku.FirstCandidate})
+		minimumRemainingValuesNodes := totalNodes(t, challenges, sudoku.SolverOptions{Heuristic: sudoku.MinimumRemainingValues})
+		houseBranchingNodes := totalNodes(t, challenges, sudoku.SolverOptions{Heuristic: sudoku.HouseBranching})
+
+		assert.Less(t, minimumRemainingValuesNodes, firstCandidateNodes)
+		assert.Less(t, houseBranchingNodes, minimumRemainingValuesNodes)
+	})
+
+	t.Run("Randomized value ordering is repeatable with the same seed", func(t *testing.T) {
+		solutionForSeed := func(seed uint64) string {
+			options := sudoku.SolverOptions{
+				Heuristic:  sudoku.MinimumRemainingValues,
+				ValueOrder: sudoku.RandomValues,
+				Seed:       seed,
+			}
+
+			result, err := sudoku.BacktrackingSolver{Options: options}.Solve(context.Background(), sudoku.EmptyGrid(3))
+			assert.NoError(t, err)
+
+			return result.Solutions[0].String()
+		}
+
+		assert.EqualValues(t, solutionForSeed(1), solutionForSeed(1))
+		assert.NotEqualValues(t, solutionForSeed(1), solutionForSeed(2))
+	})
+
+	t.Run("Unknown heuristics return an error", func(t *testing.T) {
+		_, err := sudoku.BacktrackingSolver{Options: sudoku.SolverOptions{Heuristic: 100}}.Solve(context.Background(), sudoku.EmptyGrid(2))
+		assert.Error(t, err)
+
+		_, err = sudoku.BacktrackingSolver{Options: sudoku.SolverOptions{ValueOrder: 100}}.Solve(context.Background(), sudoku.EmptyGrid(2))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,94 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// returned when an operation needs a proper challenge (one with exactly one solution), but the grid has none or several
+var ErrNoUniqueSolution = errors.New("grid doesn't have a unique solution")
+
+// checks if grid is a minimal challenge: it has a unique solution, and removing any single given would break that
+func IsMinimal(grid Grid) bool {
+	if !HasUniqueSolution(grid) {
+		return false
+	}
+
+	for i := range grid.numCells() {
+		if grid.isEmptyAt(i) {
+			continue
+		}
+
+		// grid is a copy, so this doesn't affect the caller's grid
+		challenge := grid.Clone()
+		challenge.setValueAt(i, 0)
+
+		if HasUniqueSolution(challenge) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// removes redundant givens from grid, keeping the same unique solution
+// givens are tried in the order given by order, a list of cell indexes; givens not in order are always kept,
+// so the result is only minimal with respect to the cells in order: removing any one of their givens would break uniqueness,
+// but removing a given that isn't in order might not
+// if order is nil, all cells are tried in row-by-row order, so the result is minimal (see IsMinimal())
+// different orders can lead to different minimal challenges, with different numbers of givens
+// does not modify grid
+func Minimize(grid Grid, order []int) (Grid, error) {
+	if !HasUniqueSolution(grid) {
+		return Grid{}, ErrNoUniqueSolution
+	}
+
+	if order == nil {
+		order = make([]int, grid.numCells())
+		for i := range order {
+			order[i] = i
+		}
+	}
+
+	cellGroups := [][]int{}
+	for _, cellIndex := range order {
+		if cellIndex < 0 || cellIndex >= grid.numCells() {
+			return Grid{}, fmt.Errorf("cell index %v is out of range for a grid with %v cells", cellIndex, grid.numCells())
+		}
+
+		cellGroups = append(cellGroups, []int{cellIndex})
+	}
+
+	challenge := grid.Clone()
+	// the background context is never done, so this can't fail
+	_ = removeGivensWhileUnique(context.Background(), &challenge, cellGroups, nil)
+
+	return challenge, nil
+}
+
+// tries removing each group of givens in turn, putting the group back if the challenge would no longer have a unique solution
+// if isAcceptable is non-nil, a group is also put back if isAcceptable returns false for the challenge without it
+// modifies challenge in place; challenge must already have a unique solution
+// stops early once ctx is done, returning ctx's error; challenge still has a unique solution then, but it may not be minimal
+func removeGivensWhileUnique(ctx context.Context, challenge *Grid, cellGroups [][]int, isAcceptable func(Grid) bool) error {
+	for _, group := range cellGroups {
+		withoutGroup := challenge.Clone()
+		for _, cellIndex := range group {
+			withoutGroup.setValueAt(cellIndex, 0)
+		}
+
+		// a search cut short by ctx might not have found the second solution, so its count can't be trusted
+		count, err := countSolutions(ctx, withoutGroup, 2)
+		if err != nil {
+			return err
+		}
+
+		// if the group's givens are needed, leave challenge as it is, keeping them
+		if count == 1 && (isAcceptable == nil || isAcceptable(withoutGroup)) {
+			*challenge = withoutGroup
+		}
+	}
+
+	return nil
+}
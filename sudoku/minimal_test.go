@@ -0,0 +1,104 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMinimal(t *testing.T) {
+	t.Run("A minimal 4x4 challenge", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("1......4..2..3..")
+
+		assert.True(t, sudoku.IsMinimal(grid))
+	})
+
+	t.Run("A challenge with a redundant given isn't minimal", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("14.....4..2..3..")
+
+		assert.True(t, sudoku.HasUniqueSolution(grid))
+		assert.False(t, sudoku.IsMinimal(grid))
+	})
+
+	t.Run("A challenge with multiple solutions isn't minimal", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("1...............")
+
+		assert.False(t, sudoku.IsMinimal(grid))
+	})
+
+	t.Run("Generated challenges are minimal", func(t *testing.T) {
+		challenge, err := sudoku.Generate(3, sudoku.GeneratorOptions{Seed: 3})
+		assert.NoError(t, err)
+
+		assert.True(t, sudoku.IsMinimal(challenge))
+	})
+}
+
+func TestMinimize(t *testing.T) {
+	t.Run("Minimizing a solution gives a minimal challenge with the same solution", func(t *testing.T) {
+		solution := "1432321441232341"
+		grid := sudoku.ParseSingleGrid(solution)
+
+		minimized, err := sudoku.Minimize(grid, nil)
+		assert.NoError(t, err)
+
+		assert.True(t, sudoku.IsMinimal(minimized))
+		solvedGrid := sudoku.SolveWithBacktracking(minimized)
+		assert.EqualValues(t, solution, solvedGrid.String())
+
+		// minimizing doesn't modify the original grid
+		assert.EqualValues(t, solution, grid.String())
+	})
+
+	t.Run("Givens are removed in the requested order", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("1432321441232341")
+
+		minimizedFromStart, err := sudoku.Minimize(grid, nil)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "......14.1.3.34.", minimizedFromStart.String())
+
+		reversedOrder := []int{15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+		minimizedFromEnd, err := sudoku.Minimize(grid, reversedOrder)
+		assert.NoError(t, err)
+		assert.EqualValues(t, ".43.3.1.41......", minimizedFromEnd.String())
+	})
+
+	t.Run("Givens not in the order are kept", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("1432321441232341")
+
+		minimized, err := sudoku.Minimize(grid, []int{15})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "143232144123234.", minimized.String())
+
+		// so the result is only minimal with respect to the cells in the order
+		assert.False(t, sudoku.IsMinimal(minimized))
+
+		// the first three rows are minimized, and the last row is kept as it is
+		order := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+		minimized, err = sudoku.Minimize(grid, order)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "......14.1.32341", minimized.String())
+		assert.False(t, sudoku.IsMinimal(minimized))
+
+		for _, cellIndex := range []int{6, 7, 9, 11} {
+			withoutGiven := minimized.Clone()
+			withoutGiven.Clear(minimized.RowOf(cellIndex), minimized.ColOf(cellIndex))
+			assert.False(t, sudoku.HasUniqueSolution(withoutGiven), cellIndex)
+		}
+	})
+
+	t.Run("Minimizing a grid with multiple solutions fails", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("1...............")
+
+		_, err := sudoku.Minimize(grid, nil)
+		assert.ErrorIs(t, err, sudoku.ErrNoUniqueSolution)
+	})
+
+	t.Run("Out-of-range cell indexes are rejected", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("1......4..2..3..")
+
+		_, err := sudoku.Minimize(grid, []int{16})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,230 @@
+package sudoku
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+)
+
+// the backtracking search splits off branches for other goroutines to search at depths below this; deeper branches are searched directly
+// the top few levels give plenty of subproblems to share around, without the overhead of handing off tiny subtrees
+const parallelSplitDepth = 8
+
+// a subtree of the search, waiting for a worker to search it
+type searchTask struct {
+	puzzle Puzzle
+	depth  int
+}
+
+// a double-ended queue of tasks belonging to one worker
+// the owner pushes and pops at the bottom (so it works depth-first on the most recent, smallest subtrees),
+// while other workers steal from the top (taking the oldest, usually largest, subtrees, which are worth the handoff)
+type workDeque struct {
+	mutex sync.Mutex
+	tasks []searchTask
+}
+
+func (deque *workDeque) pushBottom(task searchTask) {
+	deque.mutex.Lock()
+	defer deque.mutex.Unlock()
+
+	deque.tasks = append(deque.tasks, task)
+}
+
+func (deque *workDeque) popBottom() (searchTask, bool) {
+	deque.mutex.Lock()
+	defer deque.mutex.Unlock()
+
+	if len(deque.tasks) == 0 {
+		return searchTask{}, false
+	}
+
+	task := deque.tasks[len(deque.tasks)-1]
+	deque.tasks = deque.tasks[:len(deque.tasks)-1]
+	return task, true
+}
+
+func (deque *workDeque) stealTop() (searchTask, bool) {
+	deque.mutex.Lock()
+	defer deque.mutex.Unlock()
+
+	if len(deque.tasks) == 0 {
+		return searchTask{}, false
+	}
+
+	task := deque.tasks[0]
+	deque.tasks = deque.tasks[1:]
+	return task, true
+}
+
+// lets idle workers sleep until there might be work for them, instead of repeatedly checking every deque
+type workSignal struct {
+	mutex   sync.Mutex
+	changed *sync.Cond
+
+	// incremented whenever a task is pushed or the search ends, so a worker can tell whether anything happened since it last looked for work
+	generation uint64
+}
+
+func newWorkSignal() *workSignal {
+	signal := &workSignal{}
+	signal.changed = sync.NewCond(&signal.mutex)
+	return signal
+}
+
+// the current generation; read this before looking for work, then pass it to waitSince() if there wasn't any
+func (signal *workSignal) current() uint64 {
+	signal.mutex.Lock()
+	defer signal.mutex.Unlock()
+
+	return signal.generation
+}
+
+// wakes up one idle worker to take a newly pushed task
+func (signal *workSignal) taskPushed() {
+	signal.mutex.Lock()
+	defer signal.mutex.Unlock()
+
+	signal.generation++
+	signal.changed.Signal()
+}
+
+// wakes up every idle worker, so they can all notice that the search is over
+func (signal *workSignal) searchEnded() {
+	signal.mutex.Lock()
+	defer signal.mutex.Unlock()
+
+	signal.generation++
+	signal.changed.Broadcast()
+}
+
+// blocks until a task is pushed or the search ends, unless that's already happened since generation
+func (signal *workSignal) waitSince(generation uint64) {
+	signal.mutex.Lock()
+	defer signal.mutex.Unlock()
+
+	for signal.generation == generation {
+		signal.changed.Wait()
+	}
+}
+
+// runs a backtracking search for up to maxSolutions solutions of puzzle across a pool of worker goroutines, using work stealing
+// each worker's search is configured from template (which isn't modified); its spawn, ctx and onSolution are replaced
+// once maxSolutions solutions have been found, the remaining workers are cancelled; since the branches of the search never overlap,
+// every solution is found exactly once, so when there are fewer than maxSolutions solutions, all of them are found
+// returns the solutions in the order they were found (which varies from run to run), and the combined stats of every worker
+func runParallelSearch(
+	ctx context.Context,
+	puzzle Puzzle,
+	template backtrackingSearch,
+	workers int,
+	newWorkerRand func(worker int) *rand.Rand,
+) parallelSearchResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	deques := make([]*workDeque, workers)
+	for i := range deques {
+		deques[i] = &workDeque{}
+	}
+
+	// number of tasks that have been spawned but not finished yet; a task's children are spawned before it finishes,
+	// so this only reaches 0 when the whole search tree has been searched
+	var pendingTasks atomic.Int64
+
+	var resultMutex sync.Mutex
+	result := parallelSearchResult{}
+
+	// idle workers wait on this; the search ends when it's cancelled or when there are no pending tasks left
+	signal := newWorkSignal()
+	stopSignalling := context.AfterFunc(ctx, signal.searchEnded)
+	defer stopSignalling()
+
+	pendingTasks.Add(1)
+	deques[0].pushBottom(searchTask{puzzle: puzzle, depth: 0})
+
+	var wg sync.WaitGroup
+	for worker := range workers {
+		search := template
+		search.ctx = ctx
+		search.rng = newWorkerRand(worker)
+		search.spawnDepth = parallelSplitDepth
+		search.spawn = func(puzzle Puzzle, depth int) {
+			pendingTasks.Add(1)
+			deques[worker].pushBottom(searchTask{puzzle: puzzle, depth: depth})
+			signal.taskPushed()
+		}
+		search.onSolution = func(solution Puzzle) {
+			resultMutex.Lock()
+			defer resultMutex.Unlock()
+
+			// other workers may find solutions before they notice the cancellation, so don't go over the limit
+			if len(result.solutions) >= template.maxSolutions {
+				return
+			}
+
+			result.solutions = append(result.solutions, solution)
+			if len(result.solutions) >= template.maxSolutions {
+				cancel()
+			}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				// read before checking for cancellation and looking for work,
+				// so a cancellation or task push that happens while this worker is looking still wakes it up
+				generation := signal.current()
+				if ctx.Err() != nil {
+					break
+				}
+
+				task, ok := deques[worker].popBottom()
+				for victim := 1; !ok && victim < workers; victim++ {
+					task, ok = deques[(worker+victim)%workers].stealTop()
+				}
+
+				if !ok {
+					if pendingTasks.Load() == 0 {
+						break
+					}
+
+					// other workers are busy, and might spawn more tasks soon
+					signal.waitSince(generation)
+					continue
+				}
+
+				search.run(task.puzzle, task.depth)
+				if pendingTasks.Add(-1) == 0 {
+					// the whole search tree has been searched, so every idle worker can stop
+					signal.searchEnded()
+				}
+			}
+
+			resultMutex.Lock()
+			defer resultMutex.Unlock()
+
+			result.stats.add(search.recorder.stats)
+			if search.mostFilledCellCount > result.mostFilledCellCount {
+				result.mostFilledGrid = search.mostFilledGrid
+				result.mostFilledCellCount = search.mostFilledCellCount
+			}
+		}()
+	}
+
+	wg.Wait()
+	return result
+}
+
+// what runParallelSearch() found
+type parallelSearchResult struct {
+	solutions []Puzzle
+	stats     Stats
+
+	// the most complete grid any worker reached, as in backtrackingSearch
+	mostFilledGrid      Grid
+	mostFilledCellCount int
+}
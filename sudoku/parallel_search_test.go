@@ -0,0 +1,100 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelSearch(t *testing.T) {
+	t.Run("Counting every solution", func(t *testing.T) {
+		sequential, err := sudoku.BacktrackingSolver{
+			Options: sudoku.SolverOptions{MaxSolutions: 1000},
+		}.Solve(context.Background(), sudoku.EmptyGrid(2))
+		assert.NoError(t, err)
+
+		parallel, err := sudoku.BacktrackingSolver{
+			Options: sudoku.SolverOptions{MaxSolutions: 1000, Parallelism: 4},
+		}.Solve(context.Background(), sudoku.EmptyGrid(2))
+		assert.NoError(t, err)
+
+		assert.Len(t, parallel.Solutions, 288)
+		distinctSolutions := map[string]bool{}
+		for _, solution := range parallel.Solutions {
+			assert.True(t, solution.IsValidSolution())
+			distinctSolutions[solution.String()] = true
+		}
+		assert.Len(t, distinctSolutions, 288)
+
+		// the whole search tree is explored either way, just split up differently
+		assert.EqualValues(t, sequential.Stats.Nodes, parallel.Stats.Nodes)
+		assert.EqualValues(t, sequential.Stats.Backtracks, parallel.Stats.Backtracks)
+	})
+
+	t.Run("Solving hard challenges, checking uniqueness", func(t *testing.T) {
+		solver := sudoku.BacktrackingSolver{
+			Options: sudoku.SolverOptions{
+				MaxSolutions: 2,
+				Heuristic:    sudoku.HouseBranching,
+				Parallelism:  4,
+			},
+		}
+
+		for _, challenge := range loadExampleGrids(t, "9x9", "hardest.txt") {
+			result, err := solver.Solve(context.Background(), challenge)
+			assert.NoError(t, err)
+			assert.Len(t, result.Solutions, 1)
+			assert.True(t, result.Solutions[0].IsValidSolution())
+		}
+	})
+
+	t.Run("Other workers stop once enough solutions are found", func(t *testing.T) {
+		solver := sudoku.BacktrackingSolver{
+			Options: sudoku.SolverOptions{
+				MaxSolutions: 10,
+				Parallelism:  4,
+			},
+		}
+
+		// an empty 9x9 grid has far too many solutions to find them all, so this only finishes if the workers are stopped
+		result, err := solver.Solve(context.Background(), sudoku.EmptyGrid(3))
+		assert.NoError(t, err)
+		assert.Len(t, result.Solutions, 10)
+	})
+
+	t.Run("Filling an empty 16x16 grid", func(t *testing.T) {
+		solver := sudoku.BacktrackingSolver{
+			Options: sudoku.SolverOptions{
+				Heuristic:   sudoku.MinimumRemainingValues,
+				Parallelism: 4,
+			},
+		}
+
+		result, err := solver.Solve(context.Background(), sudoku.EmptyGrid(4))
+		assert.NoError(t, err)
+		assert.True(t, result.Solutions[0].IsValidSolution())
+	})
+
+	t.Run("A grid with conflicting givens has no solution", func(t *testing.T) {
+		solver := sudoku.BacktrackingSolver{
+			Options: sudoku.SolverOptions{Parallelism: 4},
+		}
+
+		_, err := solver.Solve(context.Background(), sudoku.ParseSingleGrid("11.............."))
+		assert.ErrorIs(t, err, sudoku.ErrNoSolution)
+	})
+
+	t.Run("A cancelled context stops every worker", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		solver := sudoku.BacktrackingSolver{
+			Options: sudoku.SolverOptions{MaxSolutions: 1000, Parallelism: 4},
+		}
+
+		_, err := solver.Solve(ctx, sudoku.EmptyGrid(3))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -4,7 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
+	"strings"
 )
 
 func LoadGridsFromFile(filename string) ([]Grid, error) {
@@ -50,12 +50,22 @@ func readFileLines(filename string) ([]string, error) {
 
 // public for testing purposes, and because a pure parsing function is useful to have
 // TODO - potentially move functions for loading from a file into a separate package?
+// values above 9 are written as letters (A = 10, B = 11, C = 12), and empty cells as '.'
+// boxes have the usual shape for the grid's size (see NewGridFromValues())
 func ParseSingleGrid(str string) Grid {
 	switch len(str) {
-	case 16:
-		return parseBaseSize2Grid(str)
-	case 81:
-		return parseBaseSize3Grid(str)
+	case 16: // 4x4
+		return parseGrid(str, EmptyGridWithBoxes(2, 2))
+	case 36: // 6x6
+		return parseGrid(str, EmptyGridWithBoxes(3, 2))
+	case 64: // 8x8
+		return parseGrid(str, EmptyGridWithBoxes(4, 2))
+	case 81: // 9x9
+		return parseGrid(str, EmptyGridWithBoxes(3, 3))
+	case 100: // 10x10
+		return parseGrid(str, EmptyGridWithBoxes(5, 2))
+	case 144: // 12x12
+		return parseGrid(str, EmptyGridWithBoxes(4, 3))
 	case 256:
 		panic("Parsing not yet implemented for 16x16 puzzles")
 	case 625:
@@ -67,52 +77,33 @@ func ParseSingleGrid(str string) Grid {
 	}
 }
 
-func parseBaseSize2Grid(str string) Grid {
-	grid := EmptyGrid(2)
+// parses a Latin square (see EmptyLatinSquare()), using the same format as ParseSingleGrid()
+// str must have a square number of characters; the side length is the square root of that
+func ParseLatinSquare(str string) Grid {
+	sideLength := 1
+	for sideLength*sideLength < len(str) {
+		sideLength++
+	}
 
-	for pos, ch := range str {
-		switch ch {
-		case '1', '2', '3', '4':
-			intValue, _ := strconv.Atoi(string(ch)) // ignore error, conversion should always be valid
-			grid.cells[pos] = &Cell{
-				index:          pos,
-				containingGrid: &grid,
-				value:          &intValue,
-			}
-		case '.':
-			grid.cells[pos] = &Cell{
-				index:          pos,
-				containingGrid: &grid,
-				value:          nil,
-			}
-		}
-		// no default case - ignore all other runes
+	if sideLength*sideLength != len(str) || sideLength > len(valueSymbols) {
+		panic(fmt.Sprintf("Unrecognized Latin square size: %v", len(str)))
 	}
 
-	return grid
+	return parseGrid(str, EmptyLatinSquare(sideLength))
 }
 
-func parseBaseSize3Grid(str string) Grid {
-	grid := EmptyGrid(3)
+// fills in the values in str, one character per cell, row by row, into grid, which must be empty
+func parseGrid(str string, grid Grid) Grid {
+	symbols := valueSymbols[:grid.maxElement()]
+	buffer := grid.buffer()
 
 	for pos, ch := range str {
-		switch ch {
-		case '1', '2', '3', '4', '5', '6', '7', '8', '9':
-			intValue, _ := strconv.Atoi(string(ch)) // ignore error, conversion should always be valid
-			grid.cells[pos] = &Cell{
-				index:          pos,
-				containingGrid: &grid,
-				value:          &intValue,
-			}
-		case '.':
-			grid.cells[pos] = &Cell{
-				index:          pos,
-				containingGrid: &grid,
-				value:          nil,
-			}
+		if value := strings.IndexRune(symbols, ch) + 1; value > 0 {
+			buffer.setValueAt(pos, value)
 		}
-		// no default case - ignore all other runes
+		// '.' is an empty cell, and cells in an empty grid are already empty
+		// ignore all other runes
 	}
 
-	return grid
+	return buffer.grid()
 }
@@ -37,6 +37,27 @@ func TestLoadGridsFromFile(t *testing.T) {
 			testLoading9x9GridsFromFile(t, filename)
 		}
 	})
+
+	t.Run("Parsing grids with non-square boxes", func(t *testing.T) {
+		boxShapes := map[string][2]int{
+			"6x6":   {3, 2},
+			"8x8":   {4, 2},
+			"10x10": {5, 2},
+			"12x12": {4, 3},
+		}
+		for sizeFolder, boxShape := range boxShapes {
+			for _, exampleFile := range []string{"filledGrid.txt", "challenges.txt"} {
+				grids, err := LoadGridsFromFile(filepath.Join(examplesFolder, sizeFolder, exampleFile))
+				assert.NoError(t, err)
+				assert.NotEmpty(t, grids)
+
+				for _, grid := range grids {
+					assert.EqualValues(t, boxShape[0], grid.boxWidth)
+					assert.EqualValues(t, boxShape[1], grid.boxHeight)
+				}
+			}
+		}
+	})
 }
 
 // tests that parsing succeeds and returns a Grid with the right size
@@ -47,7 +68,8 @@ func testLoading4x4GridsFromFile(t *testing.T, filename string) {
 	assert.NoError(t, err)
 
 	for _, grid := range grids {
-		assert.EqualValues(t, 2, grid.baseSize)
+		assert.EqualValues(t, 2, grid.boxWidth)
+		assert.EqualValues(t, 2, grid.boxHeight)
 	}
 }
 
@@ -59,7 +81,8 @@ func testLoading9x9GridsFromFile(t *testing.T, filename string) {
 	assert.NoError(t, err)
 
 	for _, grid := range grids {
-		assert.EqualValues(t, 3, grid.baseSize)
+		assert.EqualValues(t, 3, grid.boxWidth)
+		assert.EqualValues(t, 3, grid.boxHeight)
 	}
 }
 
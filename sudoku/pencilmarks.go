@@ -0,0 +1,286 @@
+package sudoku
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// formats cg over multiple lines as a pencil-mark grid, the layout most solvers use to show candidates:
+//
+//	4     1679  12679 | 139   2369  269   | 8     1239  5
+//	...
+//
+// each cell shows its candidates run together in increasing order, and each column is padded to the width of its widest cell
+// cells with a value show just that value; empty cells with no candidates left are shown as '.',
+// and empty cells with a single candidate show it in parentheses, like "(5)", so they can't be mistaken for cells with a value
+// boxes are separated like in PrettyString(), so the output can be read back with ParsePencilMarkGrid()
+// (or ParsePencilMarkLatinSquare() for a Latin square) to get a CandidateGrid equal to cg
+func (cg CandidateGrid) PencilMarkString() string {
+	grid := cg.Grid()
+
+	columnWidths := make([]int, grid.sideLength())
+	for i := range grid.numCells() {
+		col := grid.ColOf(i)
+		columnWidths[col] = max(columnWidths[col], len(cg.pencilMarks(i)))
+	}
+
+	lines := []string{}
+	for _, row := range grid.rows() {
+		var line strings.Builder
+		for i, cellIndex := range row {
+			if grid.HasBoxes() && i > 0 && i%grid.boxWidth == 0 {
+				line.WriteString(" | ")
+			} else if i > 0 {
+				line.WriteString(" ")
+			}
+			line.WriteString(fmt.Sprintf("%-*v", columnWidths[i], cg.pencilMarks(cellIndex)))
+		}
+
+		// padding the last column would only leave trailing spaces
+		formatted := strings.TrimRight(line.String(), " ")
+
+		if grid.HasBoxes() && len(lines) > 0 && grid.RowOf(row[0])%grid.boxHeight == 0 {
+			lines = append(lines, strings.Repeat("-", len(formatted)))
+		}
+		lines = append(lines, formatted)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// the candidates for the cell at cellIndex, written together as in a pencil-mark grid
+func (cg CandidateGrid) pencilMarks(cellIndex int) string {
+	candidates := cg.Candidates(cellIndex)
+	if len(candidates) == 0 {
+		return formatValue(0)
+	}
+
+	var marks strings.Builder
+	for _, value := range candidates {
+		marks.WriteString(formatValue(value))
+	}
+
+	if len(candidates) == 1 && cg.puzzle.isCellEmpty(cellIndex) {
+		return "(" + marks.String() + ")"
+	}
+	return marks.String()
+}
+
+// parses a pencil-mark grid like the ones written by CandidateGrid.PencilMarkString() and other solvers
+// each line has one row, with each cell's candidates written together and cells separated by spaces;
+// separators and rulers are allowed like in ParsePrettyGrid(), and give the box shape in the same way, including the usual box shape for a grid without any
+// as most solvers do, a cell with a single candidate is read as having that value, unless it's in parentheses, like "(5)";
+// '.' or '0' is an empty cell with no candidates
+func ParsePencilMarkGrid(str string) (CandidateGrid, error) {
+	cells, values, layout, err := parsePencilMarkCells(str)
+	if err != nil {
+		return CandidateGrid{}, err
+	}
+
+	boxWidth, boxHeight, err := layout.boxShape()
+	if err != nil {
+		return CandidateGrid{}, err
+	}
+
+	return newCandidateGridFromCells(cells, values, boxWidth, boxHeight)
+}
+
+// same as ParsePencilMarkGrid(), but parses a Latin square, like the ones printed by CandidateGrid.PencilMarkString()
+// Latin squares don't have boxes, so separators between the cells of a row or between rows are an error
+func ParsePencilMarkLatinSquare(str string) (CandidateGrid, error) {
+	cells, values, layout, err := parsePencilMarkCells(str)
+	if err != nil {
+		return CandidateGrid{}, err
+	}
+
+	boxWidth, boxHeight, err := layout.separatorBoxShape()
+	if err != nil {
+		return CandidateGrid{}, err
+	}
+	if boxWidth != 0 || boxHeight != 0 {
+		return CandidateGrid{}, fmt.Errorf("got separators for %vx%v boxes in a Latin square", boxWidth, boxHeight)
+	}
+
+	return newCandidateGridFromCells(cells, values, 0, 0)
+}
+
+// the candidates and values of each cell of a pencil-mark grid, in order of cell index, along with its layout for working out the box shape
+func parsePencilMarkCells(str string) ([][]int, []int, prettyLayout, error) {
+	layout := scanPrettyLayout(str, func(field string) []string {
+		// each field is a single cell's candidates
+		return []string{field}
+	})
+
+	cells := [][]int{}
+	values := []int{}
+	for row, rowCells := range layout.rows {
+		if len(rowCells) != len(layout.rows) {
+			return nil, nil, prettyLayout{}, fmt.Errorf("got a row with %v cells in a grid with %v rows", len(rowCells), len(layout.rows))
+		}
+
+		for _, token := range rowCells {
+			candidates, isEmpty, err := parsePencilMarks(token)
+			if err != nil {
+				return nil, nil, prettyLayout{}, fmt.Errorf("%w on line %v", err, layout.lineNumbers[row])
+			}
+
+			cells = append(cells, candidates)
+			if len(candidates) == 1 && !isEmpty {
+				values = append(values, candidates[0])
+			} else {
+				values = append(values, 0)
+			}
+		}
+	}
+
+	return cells, values, layout, nil
+}
+
+// the candidates written in a single cell of a pencil-mark grid, or an empty list for '.' or '0'
+// also returns whether the token was in parentheses, which marks an empty cell even if it has a single candidate
+func parsePencilMarks(token string) ([]int, bool, error) {
+	isEmpty := strings.HasPrefix(token, "(") && strings.HasSuffix(token, ")") && len(token) > 2
+	if isEmpty {
+		token = token[1 : len(token)-1]
+	}
+
+	candidates := []int{}
+	for _, ch := range token {
+		value, ok := parsePrettyValue(ch)
+		if !ok {
+			return nil, false, fmt.Errorf("unexpected character %q", ch)
+		}
+
+		if value != 0 {
+			candidates = append(candidates, value)
+		}
+	}
+
+	return candidates, isEmpty, nil
+}
+
+// formats cg as a compact candidate string, where each cell takes up one character per possible value:
+// the value's symbol if it's a candidate, or '0' if it's not, so the candidates for a 9x9 grid take 729 characters
+// cells with a value have just that value as a candidate, so the candidates are followed by '/' and the grid's values (as in Grid.String()),
+// which tell them apart from empty cells with a single candidate; ParseCandidateString() reads it back to a CandidateGrid equal to cg
+// other solvers usually only read the candidates, which are everything before the '/'
+func (cg CandidateGrid) CandidateString() string {
+	grid := cg.Grid()
+
+	var str strings.Builder
+	for i := range grid.numCells() {
+		for value := 1; value <= grid.maxElement(); value++ {
+			if cg.HasCandidate(i, value) {
+				str.WriteString(formatValue(value))
+			} else {
+				str.WriteString("0")
+			}
+		}
+	}
+
+	str.WriteString("/")
+	str.WriteString(grid.String())
+
+	return str.String()
+}
+
+// parses a compact candidate string, like those written by CandidateGrid.CandidateString()
+// the string doesn't say what shape the grid's boxes are, so boxWidth and boxHeight give it, as in NewGridFromValuesWithBoxes()
+// (3 and 3 for a 9x9 grid, or 0 and 0 for a Latin square)
+// the candidates' length must be the cube of the grid's side length (729 for a 9x9 grid); whitespace is ignored,
+// and a missing candidate can be written as '0' or '.'
+// the grid's values can follow after a '/', in the format read by ParseSingleGrid(); each cell with a value must have just that value as its candidate
+// without them, as in the candidate strings from other solvers, a cell with a single candidate is read as having that value
+func ParseCandidateString(str string, boxWidth int, boxHeight int) (CandidateGrid, error) {
+	str = strings.Map(func(ch rune) rune {
+		if unicode.IsSpace(ch) {
+			return -1
+		}
+		return ch
+	}, str)
+	str, valuesStr, hasValues := strings.Cut(str, "/")
+
+	sideLength := 1
+	for sideLength*sideLength*sideLength < len(str) {
+		sideLength++
+	}
+	if sideLength*sideLength*sideLength != len(str) {
+		return CandidateGrid{}, fmt.Errorf("candidate string has length %v, which isn't the cube of a side length", len(str))
+	}
+
+	cells := [][]int{}
+	for cellIndex := range sideLength * sideLength {
+		candidates := []int{}
+
+		for offset, ch := range str[cellIndex*sideLength : (cellIndex+1)*sideLength] {
+			value, ok := parsePrettyValue(ch)
+			switch {
+			case !ok:
+				return CandidateGrid{}, fmt.Errorf("unexpected character %q for cell %v", ch, cellIndex)
+			case value == 0:
+				continue
+			case value != offset+1:
+				// each value has a fixed position within the cell, so the string can't be misread
+				return CandidateGrid{}, fmt.Errorf("candidate %q for cell %v is in the position for %v", ch, cellIndex, formatValue(offset+1))
+			}
+
+			candidates = append(candidates, value)
+		}
+
+		cells = append(cells, candidates)
+	}
+
+	values := []int{}
+	if hasValues {
+		if len([]rune(valuesStr)) != len(cells) {
+			return CandidateGrid{}, fmt.Errorf("got values for %v cells, but the candidates are for %v cells", len([]rune(valuesStr)), len(cells))
+		}
+
+		for cellIndex, ch := range []rune(valuesStr) {
+			value, ok := parsePrettyValue(ch)
+			if !ok {
+				return CandidateGrid{}, fmt.Errorf("unexpected value %q for cell %v", ch, cellIndex)
+			}
+			if value != 0 && !slices.Equal(cells[cellIndex], []int{value}) {
+				return CandidateGrid{}, fmt.Errorf("cell %v has value %v, but its candidates are %v", cellIndex, formatValue(value), cells[cellIndex])
+			}
+
+			values = append(values, value)
+		}
+	} else {
+		for _, candidates := range cells {
+			if len(candidates) == 1 {
+				values = append(values, candidates[0])
+			} else {
+				values = append(values, 0)
+			}
+		}
+	}
+
+	return newCandidateGridFromCells(cells, values, boxWidth, boxHeight)
+}
+
+// creates a CandidateGrid from the candidates and value (0 for an empty cell) of each cell of a square grid, in order of cell index
+// the grid's boxes are boxWidth columns wide and boxHeight rows tall, or it's a Latin square if both are 0
+func newCandidateGridFromCells(cells [][]int, values []int, boxWidth int, boxHeight int) (CandidateGrid, error) {
+	sideLength := 0
+	for sideLength*sideLength < len(values) {
+		sideLength++
+	}
+
+	rows := make([][]int, sideLength)
+	for i, value := range values {
+		row := i / sideLength
+		rows[row] = append(rows[row], value)
+	}
+
+	// checks that the grid has the right size for its boxes, and its values are in range
+	grid, err := NewGridFromValuesWithBoxes(rows, boxWidth, boxHeight)
+	if err != nil {
+		return CandidateGrid{}, err
+	}
+
+	return NewCandidateGridFromPencilMarks(grid, cells)
+}
@@ -0,0 +1,180 @@
+package sudoku_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPencilMarks(t *testing.T) {
+	// 1 . | . .
+	// . . | . 4
+	// ----+----
+	// . . | 2 .
+	// . 3 | . .
+	challenge := sudoku.ParseSingleGrid("1......4..2..3..")
+
+	t.Run("Printing a pencil-mark grid", func(t *testing.T) {
+		candidates := sudoku.NewCandidateGrid(challenge)
+		candidates.Eliminate(5, 2)
+
+		// empty cells with a single candidate are in parentheses, to tell them apart from cells with a value
+		expected := strings.Join([]string{
+			"1   24 | (3) 23",
+			"23  .  | 13  4",
+			"---------------",
+			"(4) 14 | 2   13",
+			"24  3  | 14  (1)",
+		}, "\n")
+		assert.EqualValues(t, expected, candidates.PencilMarkString())
+	})
+
+	t.Run("Parsing a pencil-mark grid", func(t *testing.T) {
+		candidates, err := sudoku.ParsePencilMarkGrid(`
+			1   24 | (3) 23
+			23  .  | 13  4
+			---------------
+			(4) 14 | 2   13
+			24  3  | 14  (1)
+		`)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []int{2, 4}, candidates.Candidates(1))
+		assert.EqualValues(t, []int{3}, candidates.Candidates(2))
+		assert.Empty(t, candidates.Candidates(5))
+		assert.EqualValues(t, challenge.String(), candidates.Grid().String())
+
+		// without parentheses, as most solvers write them, cells with a single candidate are read as having that value
+		candidates, err = sudoku.ParsePencilMarkGrid(`
+			1  24 | 3  23
+			23 .  | 13 4
+			-------------
+			4  14 | 2  13
+			24 3  | 14 1
+		`)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "1.3....44.2..3.1", candidates.Grid().String())
+
+		_, err = sudoku.ParsePencilMarkGrid("1 2 3 4\n1 2 3\n1 2 3 4\n1 2 3 4")
+		assert.Error(t, err)
+
+		_, err = sudoku.ParsePencilMarkGrid("1 2 3 4\n1 2 3 4\n1 2 3 4\n1 2 3 x")
+		assert.Error(t, err)
+
+		_, err = sudoku.ParsePencilMarkGrid("1 2 3 4\n1 2 3 4\n1 2 3 4\n1 2 3 45")
+		assert.Error(t, err)
+	})
+
+	t.Run("Pencil-mark grids without separators have the usual box shape", func(t *testing.T) {
+		candidates := sudoku.NewCandidateGrid(loadExampleGrids(t, "9x9", "easy50.txt")[0])
+		withoutSeparators := strings.NewReplacer("|", "", "-", "").Replace(candidates.PencilMarkString())
+
+		parsed, err := sudoku.ParsePencilMarkGrid(withoutSeparators)
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(candidates))
+		assert.EqualValues(t, 3, parsed.Grid().BoxWidth())
+		assert.EqualValues(t, 3, parsed.Grid().BoxHeight())
+	})
+
+	t.Run("Round-tripping pencil-mark grids", func(t *testing.T) {
+		for _, grid := range []sudoku.Grid{challenge, loadExampleGrids(t, "9x9", "easy50.txt")[0], loadExampleGrids(t, "12x12", "challenges.txt")[0]} {
+			candidates := sudoku.NewCandidateGrid(grid)
+
+			parsed, err := sudoku.ParsePencilMarkGrid(candidates.PencilMarkString())
+			assert.NoError(t, err)
+			assert.True(t, parsed.Equal(candidates), grid.String())
+		}
+	})
+
+	t.Run("Round-tripping keeps the box shape", func(t *testing.T) {
+		tallBoxes := sudoku.EmptyGridWithBoxes(2, 3)
+		tallBoxes.Set(0, 0, 1)
+		tallBoxes.Set(5, 5, 6)
+		candidates := sudoku.NewCandidateGrid(tallBoxes)
+
+		parsed, err := sudoku.ParsePencilMarkGrid(candidates.PencilMarkString())
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(candidates))
+
+		parsed, err = sudoku.ParseCandidateString(candidates.CandidateString(), 2, 3)
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(candidates))
+	})
+
+	t.Run("Round-tripping Latin squares", func(t *testing.T) {
+		candidates := sudoku.NewCandidateGrid(sudoku.ParseLatinSquare("1....2..3....4....5......"))
+
+		parsed, err := sudoku.ParsePencilMarkLatinSquare(candidates.PencilMarkString())
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(candidates))
+
+		parsed, err = sudoku.ParseCandidateString(candidates.CandidateString(), 0, 0)
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(candidates))
+
+		// Latin squares don't have boxes to separate
+		_, err = sudoku.ParsePencilMarkLatinSquare("1 2 | 3 4\n1 2 | 3 4\n1 2 | 3 4\n1 2 | 3 4")
+		assert.Error(t, err)
+	})
+
+	t.Run("Compact candidate strings", func(t *testing.T) {
+		candidates := sudoku.NewCandidateGrid(challenge)
+		candidates.Eliminate(5, 2)
+
+		str := candidates.CandidateString()
+		assert.Len(t, str, 64+1+16)
+		assert.EqualValues(t, "1000020400300230", str[:16])
+		assert.EqualValues(t, "0000", str[20:24])
+		assert.EqualValues(t, "/"+challenge.String(), str[64:])
+
+		parsed, err := sudoku.ParseCandidateString(str, 2, 2)
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(candidates))
+
+		// '.' can also mark a missing candidate
+		parsed, err = sudoku.ParseCandidateString(strings.ReplaceAll(str[:64], "0", ".")+str[64:], 2, 2)
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(candidates))
+
+		// without the values, cells with a single candidate are read as having that value
+		parsed, err = sudoku.ParseCandidateString(str[:64], 2, 2)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "1.3....44.2..3.1", parsed.Grid().String())
+
+		_, err = sudoku.ParseCandidateString(str[1:], 2, 2)
+		assert.Error(t, err)
+
+		// the box shape has to fit the grid's size
+		_, err = sudoku.ParseCandidateString(str, 3, 3)
+		assert.Error(t, err)
+
+		// candidates must be in their value's position
+		_, err = sudoku.ParseCandidateString("0100"+str[4:], 2, 2)
+		assert.Error(t, err)
+
+		// the values have to match the candidates
+		_, err = sudoku.ParseCandidateString(str[:64]+"/2......4..2..3..", 2, 2)
+		assert.Error(t, err)
+		_, err = sudoku.ParseCandidateString(str[:64]+"/1......4..2..3.", 2, 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("9x9 candidate strings start with 729 characters of candidates", func(t *testing.T) {
+		candidates := sudoku.NewCandidateGrid(loadExampleGrids(t, "9x9", "easy50.txt")[0])
+
+		str := candidates.CandidateString()
+		assert.Len(t, str, 729+1+81)
+
+		parsed, err := sudoku.ParseCandidateString(str, 3, 3)
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(candidates))
+
+		// other solvers' candidate strings don't have the values
+		parsed, err = sudoku.ParseCandidateString(str[:729], 3, 3)
+		assert.NoError(t, err)
+		for i := range 81 {
+			assert.EqualValues(t, candidates.Candidates(i), parsed.Candidates(i), "candidates for cell %v", i)
+		}
+	})
+}
@@ -0,0 +1,276 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+// parses a grid written over multiple lines, one row per line, like the examples/*-pretty.txt files:
+//
+//	..3 | .2. | 6..
+//	9.. | 3.5 | ..1
+//	---------------
+//	...
+//
+// the layout is flexible: spaces and the separators '|', '+', '-', '=', and box-drawing characters (like '│' and '┼') can go anywhere,
+// and lines with nothing but separators (like the rulers between bands of boxes) don't count as rows
+// empty cells can be written as '.' or '0', and values above 9 as letters (A = 10, B = 11, and so on, in either case)
+// the box shape comes from where the separators fall, as printed by PrettyString(): the column separators within each row ('|', '│', '┃', or '║')
+// give the boxes' width, and the rulers between rows give their height; if there's only one kind, the other is worked out from the side length
+// a grid without any separators has the usual box shape for its size, as in NewGridFromValues(); use ParsePrettyLatinSquare() for Latin squares
+func ParsePrettyGrid(str string) (Grid, error) {
+	values, layout, err := parsePrettyValues(str)
+	if err != nil {
+		return Grid{}, err
+	}
+
+	boxWidth, boxHeight, err := layout.boxShape()
+	if err != nil {
+		return Grid{}, err
+	}
+
+	// checks that the grid is square and its values are in range
+	return NewGridFromValuesWithBoxes(values, boxWidth, boxHeight)
+}
+
+// same as ParsePrettyGrid(), but parses a Latin square, like the ones printed by PrettyString()
+// Latin squares don't have boxes, so separators between the cells of a row or between rows are an error
+func ParsePrettyLatinSquare(str string) (Grid, error) {
+	values, layout, err := parsePrettyValues(str)
+	if err != nil {
+		return Grid{}, err
+	}
+
+	boxWidth, boxHeight, err := layout.separatorBoxShape()
+	if err != nil {
+		return Grid{}, err
+	}
+	if boxWidth != 0 || boxHeight != 0 {
+		return Grid{}, fmt.Errorf("got separators for %vx%v boxes in a Latin square", boxWidth, boxHeight)
+	}
+
+	// checks that the grid is square and its values are in range
+	return NewGridFromValuesWithBoxes(values, 0, 0)
+}
+
+// the values of a grid in the format read by ParsePrettyGrid(), row by row, along with its layout for working out the box shape
+func parsePrettyValues(str string) ([][]int, prettyLayout, error) {
+	layout := scanPrettyLayout(str, func(field string) []string {
+		// each character is a cell
+		return strings.Split(field, "")
+	})
+
+	values := [][]int{}
+	for row, cells := range layout.rows {
+		rowValues := []int{}
+		for _, cell := range cells {
+			ch := []rune(cell)[0]
+			value, ok := parsePrettyValue(ch)
+			if !ok {
+				return nil, prettyLayout{}, fmt.Errorf("unexpected character %q on line %v", ch, layout.lineNumbers[row])
+			}
+			rowValues = append(rowValues, value)
+		}
+		values = append(values, rowValues)
+	}
+
+	return values, layout, nil
+}
+
+// the cells of a grid written over multiple lines, along with where its separators fall, for working out its box shape
+type prettyLayout struct {
+	// the text of each cell, row by row
+	rows [][]string
+
+	// the line each row is on, numbered from 1, for error messages
+	lineNumbers []int
+
+	// for each row, the number of cells before each of the row's column separators
+	columnSeparators [][]int
+
+	// for each ruler, the number of rows before it
+	rulers []int
+}
+
+// splits str into rows of cells, noting where the column separators and rulers are
+// each line is split into fields at the separators (see isPrettySeparator()), and splitField splits each field into cells
+// lines without any cells are rulers if they have any separators other than spaces, and are skipped if they're blank
+func scanPrettyLayout(str string, splitField func(field string) []string) prettyLayout {
+	layout := prettyLayout{}
+
+	for lineNumber, line := range strings.Split(str, "\n") {
+		cells := []string{}
+		separators := []int{}
+		field := []rune{}
+		hasSeparators := false
+
+		endField := func() {
+			if len(field) > 0 {
+				cells = append(cells, splitField(string(field))...)
+				field = field[:0]
+			}
+		}
+
+		for _, ch := range line {
+			if !isPrettySeparator(ch) {
+				field = append(field, ch)
+				continue
+			}
+
+			endField()
+			if !unicode.IsSpace(ch) {
+				hasSeparators = true
+			}
+			if isColumnSeparator(ch) {
+				separators = append(separators, len(cells))
+			}
+		}
+		endField()
+
+		switch {
+		case len(cells) > 0:
+			layout.rows = append(layout.rows, cells)
+			layout.lineNumbers = append(layout.lineNumbers, lineNumber+1)
+			layout.columnSeparators = append(layout.columnSeparators, separators)
+		case hasSeparators:
+			layout.rulers = append(layout.rulers, len(layout.rows))
+		}
+	}
+
+	return layout
+}
+
+// the box shape given by the layout's separators, or the usual box shape for its size (see defaultBoxShape()) if it has none
+func (layout prettyLayout) boxShape() (boxWidth int, boxHeight int, err error) {
+	boxWidth, boxHeight, err = layout.separatorBoxShape()
+	if err != nil {
+		return 0, 0, err
+	}
+	if boxWidth == 0 && boxHeight == 0 {
+		return defaultBoxShape(len(layout.rows))
+	}
+
+	return boxWidth, boxHeight, nil
+}
+
+// the box shape given by the layout's separators, or (0, 0) if it has none
+// separators at the edges of the grid (like a border around it) don't affect the box shape
+// returns an error if the separators aren't evenly spaced, or don't line up from one row to the next
+func (layout prettyLayout) separatorBoxShape() (boxWidth int, boxHeight int, err error) {
+	sideLength := len(layout.rows)
+
+	for _, separators := range layout.columnSeparators {
+		width, err := evenSpacing(separators, sideLength)
+		if err != nil {
+			return 0, 0, fmt.Errorf("column separators: %w", err)
+		}
+
+		// rows without separators don't say anything about the box width
+		if width == 0 {
+			continue
+		}
+		if boxWidth != 0 && width != boxWidth {
+			return 0, 0, errors.New("column separators don't line up")
+		}
+		boxWidth = width
+	}
+
+	boxHeight, err = evenSpacing(layout.rulers, sideLength)
+	if err != nil {
+		return 0, 0, fmt.Errorf("rulers: %w", err)
+	}
+
+	switch {
+	case boxWidth == 0 && boxHeight == 0:
+		return 0, 0, nil
+	case boxWidth == 0:
+		boxWidth = sideLength / boxHeight
+	case boxHeight == 0:
+		boxHeight = sideLength / boxWidth
+	}
+
+	return boxWidth, boxHeight, nil
+}
+
+// the spacing of separators that split sideLength lines into equal groups, given the number of lines before each separator
+// returns 0 if there aren't any separators between the first and last lines, or an error if they aren't evenly spaced
+func evenSpacing(positions []int, sideLength int) (int, error) {
+	interior := lo.Uniq(lo.Filter(positions, func(position int, _ int) bool {
+		return position > 0 && position < sideLength
+	}))
+	if len(interior) == 0 {
+		return 0, nil
+	}
+
+	spacing := interior[0]
+	if sideLength%spacing != 0 || len(interior) != sideLength/spacing-1 {
+		return 0, fmt.Errorf("separators after %v of %v lines aren't evenly spaced", interior, sideLength)
+	}
+	for i, position := range interior {
+		if position != (i+1)*spacing {
+			return 0, fmt.Errorf("separators after %v of %v lines aren't evenly spaced", interior, sideLength)
+		}
+	}
+
+	return spacing, nil
+}
+
+// reads a single grid in the format used by ParsePrettyGrid() from a file
+func LoadPrettyGridFromFile(filename string) (Grid, error) {
+	lines, err := readFileLines(filename)
+	if err != nil {
+		return Grid{}, err
+	}
+
+	return ParsePrettyGrid(strings.Join(lines, "\n"))
+}
+
+// separators between the boxes in a row
+func isColumnSeparator(ch rune) bool {
+	return strings.ContainsRune("|│┃║", ch)
+}
+
+func isPrettySeparator(ch rune) bool {
+	// U+2500 to U+257F is the Box Drawing block
+	isBoxDrawing := ch >= '─' && ch <= '╿'
+	return unicode.IsSpace(ch) || strings.ContainsRune("|+-=", ch) || isBoxDrawing
+}
+
+// the value for a cell's character in a pretty grid, with 0 for an empty cell
+// returns false if ch isn't a value or an empty cell
+func parsePrettyValue(ch rune) (int, bool) {
+	if ch == '.' || ch == '0' {
+		return 0, true
+	}
+
+	value := strings.IndexRune(valueSymbols, unicode.ToUpper(ch)) + 1
+	return value, value > 0
+}
+
+// formats g over multiple lines in the format read by ParsePrettyGrid(), with '|' between boxes and rulers between bands of boxes
+// Latin squares have no boxes, so they're printed without any separators, and can be read back with ParsePrettyLatinSquare()
+func (g Grid) PrettyString() string {
+	lines := []string{}
+
+	for _, row := range g.rows() {
+		var line strings.Builder
+		for i, cellIndex := range row {
+			if g.HasBoxes() && i > 0 && i%g.boxWidth == 0 {
+				line.WriteString(" | ")
+			}
+			line.WriteString(formatValue(g.valueAt(cellIndex)))
+		}
+
+		if g.HasBoxes() && len(lines) > 0 && g.RowOf(row[0])%g.boxHeight == 0 {
+			// the ruler is as wide as a row, so the separators line up with it
+			lines = append(lines, strings.Repeat("-", len(line.String())))
+		}
+		lines = append(lines, line.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,174 @@
+package sudoku_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrettyGrid(t *testing.T) {
+	currentWorkingDir, err := os.Getwd()
+	assert.NoError(t, err)
+	examplesFolder := filepath.Join(currentWorkingDir, "..", "examples")
+
+	t.Run("Loading the pretty example files", func(t *testing.T) {
+		easy50 := loadExampleGrids(t, "9x9", "easy50.txt")
+
+		for i, exampleFile := range []string{"easy50-challenge1-pretty.txt", "easy50-challenge2-pretty.txt"} {
+			grid, err := sudoku.LoadPrettyGridFromFile(filepath.Join(examplesFolder, exampleFile))
+			assert.NoError(t, err)
+			assert.True(t, grid.Equal(easy50[i]))
+		}
+	})
+
+	t.Run("Printing matches the example files", func(t *testing.T) {
+		contents, err := os.ReadFile(filepath.Join(examplesFolder, "easy50-challenge1-pretty.txt"))
+		assert.NoError(t, err)
+
+		easy50 := loadExampleGrids(t, "9x9", "easy50.txt")
+		assert.EqualValues(t, strings.TrimSpace(string(contents)), easy50[0].PrettyString())
+	})
+
+	t.Run("Round-tripping every size", func(t *testing.T) {
+		for _, sizeFolder := range []string{"4x4", "6x6", "8x8", "9x9", "10x10", "12x12"} {
+			exampleFile := "filledGrid.txt"
+			if sizeFolder == "9x9" {
+				exampleFile = "easy50.txt"
+			}
+
+			for _, grid := range loadExampleGrids(t, sizeFolder, exampleFile) {
+				parsed, err := sudoku.ParsePrettyGrid(grid.PrettyString())
+				assert.NoError(t, err)
+				assert.True(t, parsed.Equal(grid), sizeFolder)
+			}
+		}
+	})
+
+	t.Run("Printing non-square boxes", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("123456456123231564564231312645645312")
+		expected := strings.Join([]string{
+			"123 | 456",
+			"456 | 123",
+			"---------",
+			"231 | 564",
+			"564 | 231",
+			"---------",
+			"312 | 645",
+			"645 | 312",
+		}, "\n")
+		assert.EqualValues(t, expected, grid.PrettyString())
+	})
+
+	t.Run("Latin squares are printed without separators", func(t *testing.T) {
+		assert.EqualValues(t, "12.\n.31\n3.2", sudoku.ParseLatinSquare("12..313.2").PrettyString())
+	})
+
+	t.Run("Parsing other layouts", func(t *testing.T) {
+		expected := sudoku.ParseSingleGrid("1......4..2..3..")
+
+		layouts := []string{
+			// zeroes for empty cells, with spaces between every cell
+			"1 0 | 0 0\n0 0 | 0 4\n----+----\n0 0 | 2 0\n0 3 | 0 0",
+			// box-drawing characters, and a border around the whole grid
+			"┌────┬────┐\n│ 1. │ .. │\n│ .. │ .4 │\n├────┼────┤\n│ .. │ 2. │\n│ .3 │ .. │\n└────┴────┘",
+			// blank lines and trailing whitespace
+			"\n1. | ..  \n.. | .4\n\n-- + --\n.. | 2.\n.3 | ..\n\n",
+			// only column separators; the rulers can be worked out from them
+			"1. | ..\n.. | .4\n.. | 2.\n.3 | ..",
+		}
+
+		for _, layout := range layouts {
+			grid, err := sudoku.ParsePrettyGrid(layout)
+			assert.NoError(t, err)
+			assert.True(t, grid.Equal(expected), layout)
+		}
+
+		// each line is a row, so the single-line format is a grid with one row of 16 cells, which isn't square
+		_, err := sudoku.ParsePrettyGrid("1......4..2..3..")
+		assert.Error(t, err)
+	})
+
+	t.Run("The box shape comes from the separators", func(t *testing.T) {
+		grid := sudoku.EmptyGridWithBoxes(2, 3)
+		grid.Set(0, 0, 1)
+		grid.Set(5, 5, 6)
+
+		parsed, err := sudoku.ParsePrettyGrid(grid.PrettyString())
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(grid))
+		assert.EqualValues(t, 2, parsed.BoxWidth())
+		assert.EqualValues(t, 3, parsed.BoxHeight())
+
+		// only rulers; the column separators can be worked out from them
+		parsed, err = sudoku.ParsePrettyGrid("1.....\n......\n......\n------\n......\n......\n.....6")
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(grid))
+	})
+
+	t.Run("Grids without separators have the usual box shape", func(t *testing.T) {
+		grid := loadExampleGrids(t, "9x9", "easy50.txt")[0]
+		rows := []string{}
+		for row := range 9 {
+			rows = append(rows, grid.String()[row*9:(row+1)*9])
+		}
+		withoutSeparators := strings.Join(rows, "\n")
+
+		parsed, err := sudoku.ParsePrettyGrid(withoutSeparators)
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(grid))
+		assert.EqualValues(t, 3, parsed.BoxWidth())
+		assert.EqualValues(t, 3, parsed.BoxHeight())
+
+		// there's no usual box shape for a prime side length
+		_, err = sudoku.ParsePrettyGrid("1....\n.....\n..3..\n.....\n....5")
+		assert.Error(t, err)
+	})
+
+	t.Run("Round-tripping Latin squares", func(t *testing.T) {
+		for _, str := range []string{"1.\n.1", "12.\n.31\n3.2", "1...\n...4\n..2.\n.3..", "1....\n.....\n..3..\n.....\n....5"} {
+			grid := sudoku.ParseLatinSquare(strings.ReplaceAll(str, "\n", ""))
+
+			parsed, err := sudoku.ParsePrettyLatinSquare(grid.PrettyString())
+			assert.NoError(t, err)
+			assert.True(t, parsed.Equal(grid), str)
+			assert.False(t, parsed.HasBoxes(), str)
+		}
+
+		// Latin squares don't have boxes to separate
+		_, err := sudoku.ParsePrettyLatinSquare("1. | ..\n.. | .4\n----\n.. | 2.\n.3 | ..")
+		assert.Error(t, err)
+	})
+
+	t.Run("Letters for values above 9", func(t *testing.T) {
+		grid := sudoku.EmptyGridWithBoxes(4, 3)
+		grid.Set(0, 0, 10)
+		grid.Set(0, 11, 12)
+
+		parsed, err := sudoku.ParsePrettyGrid(strings.ToLower(grid.PrettyString()))
+		assert.NoError(t, err)
+		assert.True(t, parsed.Equal(grid))
+	})
+
+	t.Run("Invalid grids return an error", func(t *testing.T) {
+		invalidGrids := map[string]string{
+			"unexpected character":  "1..x\n...4\n..2.\n.3..",
+			"ragged rows":           "1...\n...4\n..2\n.3..",
+			"not square":            "1...\n...4\n..2.",
+			"value out of range":    "5...\n...4\n..2.\n.3..",
+			"empty":                 "",
+			"uneven separators":     "1.. | .\n... | 4\n..2 | .\n.3. | .",
+			"misaligned separators": "1. | ..\n.. | .4\n... | 2\n.3 | ..",
+			"uneven rulers":         "1...\n----\n...4\n..2.\n.3..",
+			"wrong box shape":       "1. | ..\n----\n.. | .4\n----\n.. | 2.\n----\n.3 | ..",
+		}
+
+		for name, str := range invalidGrids {
+			_, err := sudoku.ParsePrettyGrid(str)
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package sudoku
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+)
+
+// grids with at most this many cells have all their solutions enumerated, so they can be sampled exactly uniformly
+// 4x4 grids (16 cells, 288 solutions) are small enough; 9x9 grids (81 cells, ~6.7 * 10^21 solutions) are far too big
+const maxCellsForEnumeration = 16
+
+var (
+	// all solutions for each enumerable empty grid, computed on first use
+	enumeratedSolutions      = map[Grid][]Grid{}
+	enumeratedSolutionsMutex sync.Mutex
+)
+
+// creates a random, completely filled, valid grid
+// works in two steps:
+//  1. pick a base solution. For grids small enough to enumerate every solution (4x4), the base is drawn uniformly from all of them;
+//     for larger grids, it's found with a backtracking search that tries values in random order
+//  2. apply a uniformly random transformation that turns any solution into another solution:
+//     relabeling the digits, permuting the bands (groups of rows sharing boxes), the rows within each band,
+//     the stacks (groups of columns sharing boxes), the columns within each stack, and possibly transposing
+//
+// each transformation is a bijection on the set of solutions, so for enumerable grids, the result is exactly uniform
+// for larger grids, the result is uniform among all grids equivalent to the base,
+// but the randomized backtracking search is somewhat biased in which equivalence class the base comes from,
+// so the overall distribution is only near-uniform
+func RandomSolution(baseSize int, rng *rand.Rand) Grid {
+	return randomSolution(EmptyGrid(baseSize), rng)
+}
+
+// creates a random, completely filled, valid grid with boxes boxWidth columns wide and boxHeight rows tall, in the same way as RandomSolution()
+// transposing a grid turns its boxes on their side, so grids with non-square boxes are never transposed in step 2
+func RandomSolutionWithBoxes(boxWidth int, boxHeight int, rng *rand.Rand) Grid {
+	return randomSolution(EmptyGridWithBoxes(boxWidth, boxHeight), rng)
+}
+
+// creates a random, completely filled, valid Latin square, in the same way as RandomSolution()
+// Latin squares have no boxes, so step 2 permutes all rows and all columns freely
+func RandomLatinSquare(sideLength int, rng *rand.Rand) Grid {
+	return randomSolution(EmptyLatinSquare(sideLength), rng)
+}
+
+// creates a random solution with the same size and geometry as empty (see RandomSolution() for details)
+func randomSolution(empty Grid, rng *rand.Rand) Grid {
+	var base Grid
+
+	if empty.numCells() <= maxCellsForEnumeration {
+		solutions := allSolutions(empty)
+		base = solutions[rng.IntN(len(solutions))]
+	} else {
+		base = randomizedBacktrackingSolution(empty, rng)
+	}
+
+	return randomlyTransform(base, rng)
+}
+
+// creates a completely filled, valid grid by running a backtracking search on an empty grid,
+// trying possible values in random order
+func randomizedBacktrackingSolution(empty Grid, rng *rand.Rand) Grid {
+	search := backtrackingSearch{
+		ctx:          context.Background(),
+		maxSolutions: 1,
+		valueOrder:   RandomValues,
+		rng:          rng,
+	}
+	search.run(newPuzzle(empty), 0)
+
+	if search.solutionCount == 0 {
+		panic("couldn't fill an empty grid, even though every empty grid has solutions!")
+	}
+
+	return search.firstSolution.underlyingGrid.grid()
+}
+
+// every solution for an empty grid
+// only feasible for small grids; results are cached, so callers must not modify the returned slice
+func allSolutions(empty Grid) []Grid {
+	enumeratedSolutionsMutex.Lock()
+	defer enumeratedSolutionsMutex.Unlock()
+
+	if solutions, ok := enumeratedSolutions[empty]; ok {
+		return solutions
+	}
+
+	solutions := []Grid{}
+	search := backtrackingSearch{
+		ctx: context.Background(),
+		// no real limit; the search will stop when it runs out of possibilities
+		maxSolutions: int(^uint(0) >> 1),
+		onSolution: func(solution Puzzle) {
+			solutions = append(solutions, solution.underlyingGrid.grid())
+		},
+	}
+	search.run(newPuzzle(empty), 0)
+
+	enumeratedSolutions[empty] = solutions
+	return solutions
+}
+
+// applies a uniformly random validity-preserving transformation to a solution (see RandomSolution() for details)
+// returns a new grid; doesn't modify solution
+func randomlyTransform(solution Grid, rng *rand.Rand) Grid {
+	sideLength := solution.sideLength()
+
+	// relabeling[v - 1] is the new value for cells with value v
+	relabeling := rng.Perm(solution.maxElement())
+
+	// rowOrder[r] is the row in the original solution that becomes row r in the transformed grid
+	// permuting bands and the rows within each band keeps every box's cells together in the same box, so the result is still valid
+	// each band is linesPerBand lines wide; rows are grouped into bands of boxHeight rows, columns into stacks of boxWidth columns
+	// Latin squares have no boxes to keep together, so all their rows (or columns) can be permuted freely, as one big band
+	randomLineOrder := func(linesPerBand int) []int {
+		if linesPerBand == 0 {
+			linesPerBand = sideLength
+		}
+
+		lineOrder := make([]int, 0, sideLength)
+		for _, band := range rng.Perm(sideLength / linesPerBand) {
+			for _, lineWithinBand := range rng.Perm(linesPerBand) {
+				lineOrder = append(lineOrder, band*linesPerBand+lineWithinBand)
+			}
+		}
+		return lineOrder
+	}
+	rowOrder := randomLineOrder(solution.boxHeight)
+	colOrder := randomLineOrder(solution.boxWidth)
+
+	// transposing turns a grid's boxes on their side, so it only keeps the solution valid if the boxes are square
+	transpose := solution.boxWidth == solution.boxHeight && rng.IntN(2) == 1
+
+	transformed := solution.emptied().buffer()
+	for row := 0; row < sideLength; row++ {
+		for col := 0; col < sideLength; col++ {
+			originalRow, originalCol := rowOrder[row], colOrder[col]
+			if transpose {
+				originalRow, originalCol = rowOrder[col], colOrder[row]
+			}
+
+			transformed.setValueAt(solution.cellIndex(row, col), relabeling[solution.Get(originalRow, originalCol)-1]+1)
+		}
+	}
+
+	return transformed.grid()
+}
@@ -0,0 +1,194 @@
+package sudoku_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomSolution(t *testing.T) {
+	t.Run("Random solutions are valid", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(1, 2))
+
+		for _, baseSize := range []int{2, 3} {
+			for range 10 {
+				solution := sudoku.RandomSolution(baseSize, rng)
+				assert.True(t, solution.IsValidSolution())
+			}
+		}
+	})
+
+	t.Run("Random solutions with non-square boxes are valid", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(5, 6))
+
+		for _, boxShape := range [][2]int{{3, 2}, {2, 3}, {4, 3}} {
+			for range 5 {
+				solution := sudoku.RandomSolutionWithBoxes(boxShape[0], boxShape[1], rng)
+				assert.True(t, solution.IsValidSolution())
+				assert.EqualValues(t, boxShape[0], solution.BoxWidth())
+				assert.EqualValues(t, boxShape[1], solution.BoxHeight())
+			}
+		}
+	})
+
+	t.Run("Random Latin squares are valid", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(7, 8))
+
+		for _, sideLength := range []int{2, 4, 7} {
+			seen := map[sudoku.Grid]bool{}
+			for range 10 {
+				square := sudoku.RandomLatinSquare(sideLength, rng)
+				assert.True(t, square.IsValidSolution())
+				assert.False(t, square.HasBoxes())
+				seen[square] = true
+			}
+
+			// there are only 2 Latin squares with side length 2, so they can't all be different
+			assert.Greater(t, len(seen), 1)
+		}
+	})
+
+	t.Run("Random 4x4 solutions are uniformly distributed", func(t *testing.T) {
+		const numSolutions = 288 // total number of valid 4x4 grids
+		const samplesPerSolution = 40
+		const numSamples = numSolutions * samplesPerSolution
+
+		assert.EqualValues(t, numSolutions, sudoku.CountSolutions(sudoku.EmptyGrid(2), 1000))
+
+		rng := rand.New(rand.NewPCG(3, 4))
+		occurrences := map[string]int{}
+		for range numSamples {
+			solution := sudoku.RandomSolution(2, rng)
+			occurrences[solution.String()]++
+		}
+
+		// every solution should show up
+		assert.Len(t, occurrences, numSolutions)
+
+		// Pearson's chi-squared test against the uniform distribution
+		chiSquared := 0.0
+		for _, count := range occurrences {
+			deviation := float64(count - samplesPerSolution)
+			chiSquared += deviation * deviation / samplesPerSolution
+		}
+
+		// critical value for 287 degrees of freedom at a significance level of 0.001
+		// the RNG is seeded, so this test is deterministic; this just checks the distribution isn't noticeably skewed
+		const criticalValue = 366.8
+		assert.Less(t, chiSquared, criticalValue)
+	})
+
+	// larger grids aren't enumerated, so their randomness comes from the transformation applied to the backtracking search's solution
+	t.Run("Transformed solutions are evenly spread", func(t *testing.T) {
+		// each 9x9 solution takes a lot longer to find, so there are fewer samples of them
+		for _, boxShape := range [][3]int{{3, 2, 2000}, {3, 3, 500}} {
+			boxWidth, boxHeight, numSamples := boxShape[0], boxShape[1], boxShape[2]
+			sideLength := boxWidth * boxHeight
+
+			rng := rand.New(rand.NewPCG(9, 10))
+			seen := map[sudoku.Grid]bool{}
+
+			// how often each value lands in each cell, which shows whether the values are relabeled evenly
+			valueCounts := make([][]int, sideLength*sideLength)
+			for i := range valueCounts {
+				valueCounts[i] = make([]int, sideLength+1)
+			}
+
+			// how often each cell has the same value as the top-left cell, which shows whether the rows and columns are shuffled evenly
+			// (since that doesn't depend on how the values are labeled)
+			matchCounts := make([]int, sideLength*sideLength)
+
+			for range numSamples {
+				solution := sudoku.RandomSolutionWithBoxes(boxWidth, boxHeight, rng)
+				assert.True(t, solution.IsValidSolution())
+				seen[solution] = true
+
+				for row := range sideLength {
+					for col := range sideLength {
+						value := solution.Get(row, col)
+						valueCounts[row*sideLength+col][value]++
+						if value == solution.Get(0, 0) {
+							matchCounts[row*sideLength+col]++
+						}
+					}
+				}
+			}
+
+			// there are far more solutions than samples, so repeats should be rare
+			assert.Greater(t, len(seen), numSamples*9/10, "%vx%v boxes", boxWidth, boxHeight)
+
+			// Pearson's chi-squared test of each cell's values against the uniform distribution
+			chiSquared := 0.0
+			for _, counts := range valueCounts {
+				chiSquared += chiSquaredAgainstMean(counts[1:])
+			}
+			// critical value at a significance level of 0.001, with sideLength - 1 degrees of freedom for each cell
+			degreesOfFreedom := (sideLength - 1) * sideLength * sideLength
+			assert.Less(t, chiSquared, chiSquaredCriticalValue(degreesOfFreedom), "values in cells, %vx%v boxes", boxWidth, boxHeight)
+
+			// the cells that can share the top-left cell's value fall into three groups that the transformations shuffle among themselves:
+			// the rest of the top band, the rest of the left stack, and everything else
+			// every cell in a group should match the top-left cell about equally often
+			groups := map[string][]int{}
+			for row := range sideLength {
+				for col := range sideLength {
+					sameBand, sameStack := row < boxHeight, col < boxWidth
+					switch {
+					case row == 0 && col == 0:
+						continue
+					case row == 0 || col == 0 || (sameBand && sameStack):
+						assert.Zero(t, matchCounts[row*sideLength+col], "a peer of the top-left cell can't share its value")
+					case sameBand:
+						groups["band"] = append(groups["band"], matchCounts[row*sideLength+col])
+					case sameStack:
+						groups["stack"] = append(groups["stack"], matchCounts[row*sideLength+col])
+					default:
+						groups["other"] = append(groups["other"], matchCounts[row*sideLength+col])
+					}
+				}
+			}
+			for name, counts := range groups {
+				assert.Less(t, chiSquaredAgainstMean(counts), chiSquaredCriticalValue(len(counts)-1), "%v group, %vx%v boxes", name, boxWidth, boxHeight)
+			}
+		}
+	})
+
+	t.Run("Random 9x9 solutions vary", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(5, 6))
+
+		seen := map[string]bool{}
+		for range 20 {
+			solution := sudoku.RandomSolution(3, rng)
+			seen[solution.String()] = true
+		}
+
+		assert.Len(t, seen, 20)
+	})
+}
+
+// Pearson's chi-squared statistic for counts against a uniform distribution with the same total
+func chiSquaredAgainstMean(counts []int) float64 {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	expected := float64(total) / float64(len(counts))
+
+	chiSquared := 0.0
+	for _, count := range counts {
+		deviation := float64(count) - expected
+		chiSquared += deviation * deviation / expected
+	}
+
+	return chiSquared
+}
+
+// approximate critical value of the chi-squared distribution at a significance level of 0.001, using the Wilson-Hilferty transformation
+func chiSquaredCriticalValue(degreesOfFreedom int) float64 {
+	const z = 3.090 // the standard normal distribution's critical value at 0.001
+	k := float64(degreesOfFreedom)
+	return k * math.Pow(1-2/(9*k)+z*math.Sqrt(2/(9*k)), 3)
+}
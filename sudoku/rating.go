@@ -0,0 +1,133 @@
+package sudoku
+
+import (
+	"context"
+)
+
+// a difficulty tier for challenges, based on the hardest technique needed to solve them
+// tiers are ordered from easiest to hardest, so they can be compared with < and >
+type Difficulty int
+
+const (
+	_ Difficulty = iota // zero value means "no difficulty specified"
+	Easy
+	Medium
+	Hard
+	Expert
+	Extreme // can't be solved with any implemented technique; needs search (or a technique this package doesn't implement)
+)
+
+func (d Difficulty) String() string {
+	switch d {
+	case Easy:
+		return "Easy"
+	case Medium:
+		return "Medium"
+	case Hard:
+		return "Hard"
+	case Expert:
+		return "Expert"
+	case Extreme:
+		return "Extreme"
+	default:
+		return "unspecified"
+	}
+}
+
+// the result of rating a challenge with Rate()
+type Rating struct {
+	// whether the implemented techniques were enough to completely solve the challenge
+	Solved bool
+
+	Difficulty Difficulty
+
+	// the hardest technique that was needed to make progress; unspecified if the challenge was already solved
+	HardestTechnique Technique
+
+	// how many times each technique was used to make progress
+	TechniqueCounts map[Technique]int
+}
+
+// checks if solving the challenge needed technique at least once
+func (r Rating) Uses(technique Technique) bool {
+	return r.TechniqueCounts[technique] > 0
+}
+
+// rates how difficult a challenge is for a human, by solving it with the easiest technique that makes progress at each step
+// does not modify grid
+func Rate(grid Grid) Rating {
+	rating := Rating{
+		TechniqueCounts: map[Technique]int{},
+	}
+
+	puzzle := newPuzzle(grid.Clone())
+
+	recorder := solveRecorder{
+		observer: func(event Event) {
+			if event.Kind == TechniqueEvent {
+				rating.TechniqueCounts[event.Technique]++
+				rating.HardestTechnique = max(rating.HardestTechnique, event.Technique)
+			}
+		},
+	}
+
+	outcome := puzzle.applyTechniques(context.Background(), allTechniques, &recorder)
+	rating.Solved = outcome == techniquesSolved
+
+	switch {
+	case !rating.Solved:
+		rating.Difficulty = Extreme
+	case rating.HardestTechnique == 0:
+		// the challenge was already solved, so it's as easy as it gets
+		rating.Difficulty = Easy
+	default:
+		rating.Difficulty = rating.HardestTechnique.Difficulty()
+	}
+
+	return rating
+}
+
+// describes what difficulty of challenge to generate; fields left as their zero value aren't checked
+type DifficultyTarget struct {
+	// the challenge's difficulty tier must be exactly this one
+	Difficulty Difficulty
+
+	// every one of these techniques must be needed to solve the challenge
+	RequiredTechniques []Technique
+
+	// no technique harder than this can be needed to solve the challenge (so the challenge must be solvable with techniques)
+	HardestAllowedTechnique Technique
+}
+
+// checks if a challenge with this rating matches every part of the target
+func (target DifficultyTarget) isMetBy(rating Rating) bool {
+	if target.isExceededBy(rating) {
+		return false
+	}
+
+	if target.Difficulty != 0 && rating.Difficulty != target.Difficulty {
+		return false
+	}
+
+	for _, technique := range target.RequiredTechniques {
+		if !rating.Uses(technique) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checks if a challenge with this rating is too hard for the target
+// removing more givens from such a challenge is unlikely to ever make it match the target
+func (target DifficultyTarget) isExceededBy(rating Rating) bool {
+	if target.Difficulty != 0 && rating.Difficulty > target.Difficulty {
+		return true
+	}
+
+	if target.HardestAllowedTechnique != 0 && (!rating.Solved || rating.HardestTechnique > target.HardestAllowedTechnique) {
+		return true
+	}
+
+	return false
+}
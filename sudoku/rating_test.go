@@ -0,0 +1,54 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRate(t *testing.T) {
+	t.Run("A challenge solvable with naked singles is Easy", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("..3.2.6..9..3.5..1..18.64....81.29..7.......8..67.82....26.95..8..2.3..9..5.1.3..")
+		rating := sudoku.Rate(grid)
+
+		assert.True(t, rating.Solved)
+		assert.EqualValues(t, sudoku.Easy, rating.Difficulty)
+		assert.EqualValues(t, sudoku.NakedSingle, rating.HardestTechnique)
+	})
+
+	t.Run("A challenge needing an X-Wing is Hard", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid(".43.8.25.6.............1.949....4.7....6.8....1.2....382.5.............5.34.9.71.")
+		rating := sudoku.Rate(grid)
+
+		assert.True(t, rating.Solved)
+		assert.EqualValues(t, sudoku.Hard, rating.Difficulty)
+		assert.EqualValues(t, sudoku.XWing, rating.HardestTechnique)
+		assert.True(t, rating.Uses(sudoku.HiddenPair))
+	})
+
+	t.Run("A challenge needing an XY-Wing is Expert", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("..9.....3.....9...7.....5.6..65..4.....3......28......3..75.6..6...........12.3.8")
+		rating := sudoku.Rate(grid)
+
+		assert.True(t, rating.Solved)
+		assert.EqualValues(t, sudoku.Expert, rating.Difficulty)
+		assert.True(t, rating.Uses(sudoku.XYWing))
+	})
+
+	t.Run("A challenge that can't be solved with the implemented techniques is Extreme", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("..53.....8......2..7..1.5..4....53...1..7...6..32...8..6.5....9..4....3......97..")
+		rating := sudoku.Rate(grid)
+
+		assert.False(t, rating.Solved)
+		assert.EqualValues(t, sudoku.Extreme, rating.Difficulty)
+	})
+
+	t.Run("Rating doesn't modify the grid", func(t *testing.T) {
+		challenge := ".43.8.25.6.............1.949....4.7....6.8....1.2....382.5.............5.34.9.71."
+		grid := sudoku.ParseSingleGrid(challenge)
+		sudoku.Rate(grid)
+
+		assert.EqualValues(t, challenge, grid.String())
+	})
+}
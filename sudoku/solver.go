@@ -1,6 +1,9 @@
 package sudoku
 
 import (
+	"context"
+	"math/rand/v2"
+
 	"github.com/DylanSp/sudoku-toolkit/utils"
 )
 
@@ -8,12 +11,21 @@ import (
 // It's currently exported because gopls won't allow renaming it to "puzzle" due to potentially shadowing parameter names
 // this might be a gopls bug - see https://github.com/golang/go/issues/66150
 type Puzzle struct {
-	underlyingGrid Grid
+	// a gridBuffer rather than a Grid, since solving fills in cells one at a time
+	underlyingGrid gridBuffer
 
-	// possibleValues[i]: possible values for the cell at knownValues.cells[i]
-	// invariant: len(possibleValues) == len(knownValues.cells)
+	// possibleValues[i]: possible values for the cell at index i of underlyingGrid
+	// invariant: len(possibleValues) == underlyingGrid.numCells()
 	// TODO - uses int to represent values - should it use a different representation?
 	possibleValues []utils.Set[int]
+
+	// peerIndexes[i]: indexes of all peers of the cell at index i of underlyingGrid
+	// precalculated once when the puzzle is created, since it's needed for every elimination pass
+	peerIndexes [][]int
+
+	// indexes of the cells in each house (all rows, then all columns, then all boxes)
+	// precalculated along with peerIndexes, for strategies that look at a whole house at once
+	houseIndexes [][]int
 }
 
 func SolveWithBasicStrategies(grid Grid) Grid {
@@ -28,8 +40,8 @@ func SolveWithBasicStrategies(grid Grid) Grid {
 		anyValuesEliminated := puzzle.eliminatePossibilitiesByRules()
 		anyValuesAssigned := puzzle.assignValuesForSinglePossibilities()
 
-		if puzzle.underlyingGrid.IsValidSolution() {
-			return puzzle.underlyingGrid
+		if puzzle.underlyingGrid.isValidSolution() {
+			return puzzle.underlyingGrid.grid()
 		}
 
 		// no progress made and puzzle is still incomplete
@@ -48,123 +60,272 @@ func SolveWithBacktracking(grid Grid) Grid {
 
 	puzzle := newPuzzle(grid)
 
-	solution, ok := attemptBacktrackingSolve(puzzle)
+	solution, ok := attemptBacktrackingSolve(context.Background(), puzzle)
 	if !ok {
 		// TODO - more graceful error handling
 		panic("Unable to solve puzzle with backtracking")
 	}
 
-	return solution.underlyingGrid
+	return solution.underlyingGrid.grid()
 }
 
 // attempts to solve a puzzle with recursive, backtracking search
 // if it finds a valid solution, will return (solution, true)
-// if it can't find a valid solution, will return (<unfinished puzzle>, false)
+// if it can't find a valid solution (or ctx is done before it finds one), will return (<unfinished puzzle>, false)
 // TODO - better name?
-func attemptBacktrackingSolve(puzzle Puzzle) (Puzzle, bool) {
+func attemptBacktrackingSolve(ctx context.Context, puzzle Puzzle) (Puzzle, bool) {
+	search := backtrackingSearch{
+		ctx:          ctx,
+		maxSolutions: 1,
+	}
+	search.run(puzzle, 0)
 
-	// TODO - we need a way to detect when we've encountered an invalid search branch (no candidates remain for at least one empty cell)
-	// then return false in that case, so solver can backtract
-	// not sure where that should go in this control flow
+	if search.solutionCount == 0 {
+		return puzzle, false
+	}
 
-	for {
+	return search.firstSolution, true
+}
 
-		// apply basic rules and assignments as long as possible, until either the grid is completed or no progress can be made
-		for {
-			anyValuesEliminated := puzzle.eliminatePossibilitiesByRules()
+// counts how many distinct solutions the grid has, stopping early once maxSolutions solutions have been found
+// a grid whose givens conflict with each other has 0 solutions
+// does not modify grid
+func CountSolutions(grid Grid, maxSolutions int) int {
+	if maxSolutions < 1 {
+		return 0
+	}
 
-			// TODO - check here to see if there's an empty cell with no possibilities left, and return false if so?
+	count, _ := countSolutions(context.Background(), grid, maxSolutions)
+	return count
+}
 
-			anyValuesAssigned := puzzle.assignValuesForSinglePossibilities()
+// same as CountSolutions(), but stops early once ctx is done, returning ctx's error
+// the count is only meaningful if the error is nil, since the search may not have gotten to some solutions
+func countSolutions(ctx context.Context, grid Grid, maxSolutions int) (int, error) {
+	if maxSolutions < 1 {
+		return 0, nil
+	}
 
-			if puzzle.underlyingGrid.IsValidSolution() {
-				return puzzle, true
-			}
+	search := backtrackingSearch{
+		ctx:          ctx,
+		maxSolutions: maxSolutions,
+	}
+	search.run(newPuzzle(grid.Clone()), 0)
 
-			// no progress made and puzzle is still incomplete - break out of inner loop and start searching
-			if !anyValuesEliminated && !anyValuesAssigned {
-				break
-			}
-		}
+	return search.solutionCount, ctx.Err()
+}
 
-		// puzzle isn't complete, no progress can be made with simple strategies
-		// all remaining empty cells have at least 2 possibilities
+// checks if the grid has exactly one solution, i.e. whether it's a proper challenge
+func HasUniqueSolution(grid Grid) bool {
+	return CountSolutions(grid, 2) == 1
+}
 
-		// declared locally because this isn't useful outside this function
-		// takes a puzzle as a parameter instead of closing over the existing puzzle to avoid any weirdness with captured values in recursive calls
-		// TODO - move this into separate function?
-		findFirstSearchCandidate := func(puzzle Puzzle) int {
-			for i, cellPossibilities := range puzzle.possibleValues {
-				if cellPossibilities.Size() < 2 {
-					continue
-				}
+// settings and results for a single recursive backtracking search
+type backtrackingSearch struct {
+	// the search stops early (as if it had found enough solutions) once ctx is done
+	ctx context.Context
 
-				return i
-			}
+	maxSolutions int // stop searching once this many solutions have been found
+
+	// how to pick what to branch on, and what order to try a cell's possible values in; the zero values are FirstCandidate and IncreasingValues
+	heuristic  Heuristic
+	valueOrder ValueOrder
 
-			panic("couldn't find a cell with at least 2 possibilities, even though there should be one!")
+	// source of randomness for RandomCandidate and RandomValues; must be non-nil if either of them is used
+	rng *rand.Rand
+
+	// if non-nil, called with each solution as it's found
+	onSolution func(solution Puzzle)
+
+	solutionCount int
+	firstSolution Puzzle // only meaningful if solutionCount > 0
+
+	// collects stats about the search, and passes events on to the observer (if any)
+	recorder solveRecorder
+
+	// if non-nil, every branch but the first at depths less than spawnDepth is handed to spawn instead of being searched here,
+	// so other goroutines can search it in parallel
+	spawn      func(puzzle Puzzle, depth int)
+	spawnDepth int
+
+	// the grid with the most filled cells that the search has reached so far, to report partial progress if it's stopped early
+	// the zero value means the search hasn't visited any consistent state yet
+	mostFilledGrid      Grid
+	mostFilledCellCount int
+}
+
+// recursively searches for solutions to puzzle, recording any solutions found in search
+// returns true iff the search is finished (maxSolutions solutions have been found, or ctx is done), so callers should stop searching
+// depth is how many branching decisions were made to reach puzzle
+func (search *backtrackingSearch) run(puzzle Puzzle, depth int) bool {
+	if search.ctx.Err() != nil {
+		return true
+	}
+
+	search.recorder.nodeVisited(depth)
+
+	// apply basic rules and assignments as long as possible; if that leads to a contradiction, this search branch is a dead end
+	if !puzzle.applyBasicStrategies(&search.recorder) {
+		search.recorder.backtracked(depth)
+		return false
+	}
+
+	filledCellCount := puzzle.underlyingGrid.filledCellCount()
+	if filledCellCount > search.mostFilledCellCount {
+		search.mostFilledGrid = puzzle.underlyingGrid.grid()
+		search.mostFilledCellCount = filledCellCount
+	}
+
+	if puzzle.underlyingGrid.isValidSolution() {
+		if search.solutionCount == 0 {
+			search.firstSolution = puzzle
 		}
+		search.solutionCount++
+		search.recorder.solutionFound(depth)
 
-		// find the first (empty) cell with at least 2 possibilities,
-		// pick one possibility and set it, remembering other possibilities in case search fails,
-		// then recursively search
-		searchCandidateIndex := findFirstSearchCandidate(puzzle)
-		possibilitiesForSearchCell := puzzle.possibleValues[searchCandidateIndex]
-
-		var valueChosenForSearch int
-		remainingPossibilities := []int{}
-		for i, possibility := range possibilitiesForSearchCell.Elements() {
-			if i == 0 {
-				valueChosenForSearch = possibility
-			} else {
-				remainingPossibilities = append(remainingPossibilities, possibility)
-			}
+		if search.onSolution != nil {
+			search.onSolution(puzzle)
 		}
 
-		possibilitiesForSearchCell.DeleteAll()
-		possibilitiesForSearchCell.Add(valueChosenForSearch)
-		puzzle.underlyingGrid.cells[searchCandidateIndex].value = &valueChosenForSearch
+		return search.solutionCount >= search.maxSolutions
+	}
+
+	// puzzle isn't complete, no progress can be made with simple strategies
+	// all remaining empty cells have at least 2 possibilities
+
+	// pick what to branch on (by default, the first empty cell with at least 2 possibilities),
+	// then try each branch in turn, recursively searching a copy of the puzzle with that value set,
+	// so the original puzzle is left untouched if we need to backtrack
+	searchBranches := chooseBranches(&puzzle, search.heuristic, search.valueOrder, search.rng)
+	if len(searchBranches) == 0 {
+		search.recorder.backtracked(depth)
+		return false
+	}
+
+	if search.spawn != nil && depth < search.spawnDepth {
+		// spawned in reverse order, so if nobody else picks them up, they're searched in order once the first branch is done
+		for i := len(searchBranches) - 1; i > 0; i-- {
+			search.recorder.branched(depth, searchBranches[i].cellIndex, searchBranches[i].value)
 
-		// TODO - do we need to do some sort of deep clone on `puzzle` before calling this,
-		// so if we need to backtrack, the original `puzzle` is still in the state it was before searching?
-		possibleSolution, ok := attemptBacktrackingSolve(puzzle)
-		if ok {
-			// valid solution found, return it
-			return possibleSolution, true
+			branch := puzzle.clone()
+			branch.assignValue(searchBranches[i].cellIndex, searchBranches[i].value)
+			search.spawn(branch, depth+1)
 		}
 
-		// search based on the chosen value didn't find a solution; eliminate it, then return to start of loop
-		possibilitiesForSearchCell.DeleteAll()
-		for _, possibility := range remainingPossibilities {
-			possibilitiesForSearchCell.Add(possibility)
+		searchBranches = searchBranches[:1]
+	}
+
+	for _, searchBranch := range searchBranches {
+		search.recorder.branched(depth, searchBranch.cellIndex, searchBranch.value)
+
+		branch := puzzle.clone()
+		branch.assignValue(searchBranch.cellIndex, searchBranch.value)
+
+		if search.run(branch, depth+1) {
+			return true
 		}
-		puzzle.underlyingGrid.cells[searchCandidateIndex].value = nil
 	}
+
+	// every possibility has been searched; the caller will backtrack or report the results
+	return false
 }
 
 func newPuzzle(grid Grid) Puzzle {
 	puzzle := Puzzle{
-		underlyingGrid: grid,
-		possibleValues: make([]utils.Set[int], len(grid.cells)),
+		underlyingGrid: grid.buffer(),
+		possibleValues: make([]utils.Set[int], grid.numCells()),
+		peerIndexes:    make([][]int, grid.numCells()),
+		houseIndexes:   grid.houseIndexes(),
 	}
 
-	for i, cell := range grid.cells {
-		if cell.isEmpty() {
-			puzzle.possibleValues[i] = allPossibilities(grid.baseSize)
+	for i := range grid.numCells() {
+		puzzle.peerIndexes[i] = grid.peerIndexes(i)
+
+		if grid.isEmptyAt(i) {
+			puzzle.possibleValues[i] = allPossibilities(grid.maxElement())
 		} else {
 			puzzle.possibleValues[i] = utils.Set[int]{}
-			puzzle.possibleValues[i].Add(*cell.value)
+			puzzle.possibleValues[i].Add(grid.valueAt(i))
 		}
 	}
 
 	return puzzle
 }
 
-// returns a set with all possible elements for a grid with the given base size
-func allPossibilities(baseSize int) utils.Set[int] {
+// returns a deep copy of puzzle; changes to the copy's values and possibilities don't affect the original
+func (puzzle *Puzzle) clone() Puzzle {
+	cloned := Puzzle{
+		underlyingGrid: puzzle.underlyingGrid.clone(),
+		possibleValues: make([]utils.Set[int], len(puzzle.possibleValues)),
+		peerIndexes:    puzzle.peerIndexes,  // never modified after newPuzzle(), so it can be shared
+		houseIndexes:   puzzle.houseIndexes, // same as peerIndexes
+	}
+
+	for i := range puzzle.possibleValues {
+		cloned.possibleValues[i] = puzzle.possibleValues[i].Clone()
+	}
+
+	return cloned
+}
+
+// sets the value of the cell at cellIndex, narrowing its possibilities down to just that value
+func (puzzle *Puzzle) assignValue(cellIndex int, value int) {
+	puzzle.possibleValues[cellIndex].DeleteAll()
+	puzzle.possibleValues[cellIndex].Add(value)
+	puzzle.underlyingGrid.setValueAt(cellIndex, value)
+}
+
+// apply basic rules and assignments as long as possible, until either the grid is completed or no progress can be made
+// returns false if this leads to a contradiction, meaning the puzzle can't be solved from its current state
+func (puzzle *Puzzle) applyBasicStrategies(recorder *solveRecorder) bool {
+	for {
+		possibilitiesBefore := puzzle.possibilityCount()
+		anyValuesEliminated := puzzle.eliminatePossibilitiesByRules()
+		recorder.propagated(possibilitiesBefore - puzzle.possibilityCount())
+
+		if puzzle.hasContradiction() {
+			return false
+		}
+
+		filledCellsBefore := puzzle.underlyingGrid.filledCellCount()
+		anyValuesAssigned := puzzle.assignValuesForSinglePossibilities()
+		if anyValuesAssigned {
+			recorder.techniqueApplied(NakedSingle, puzzle.underlyingGrid.filledCellCount()-filledCellsBefore)
+		}
+
+		// no progress made - either the grid is complete, or searching is needed
+		if !anyValuesEliminated && !anyValuesAssigned {
+			return true
+		}
+	}
+}
+
+// checks if the puzzle has reached a state that can't lead to a solution:
+// either an empty cell has no possibilities left, or two peers have the same value
+func (puzzle *Puzzle) hasContradiction() bool {
+	grid := puzzle.underlyingGrid
+	for i := range grid.numCells() {
+		if grid.isEmptyAt(i) {
+			if puzzle.possibleValues[i].Size() == 0 {
+				return true
+			}
+			continue
+		}
+
+		for _, peerIndex := range puzzle.peerIndexes[i] {
+			if grid.valueAt(peerIndex) == grid.valueAt(i) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// returns a set with all possible elements for a grid whose largest element is maxElement
+func allPossibilities(maxElement int) utils.Set[int] {
 	possibilities := utils.Set[int]{}
 
-	maxElement := baseSize * baseSize
 	for i := 1; i <= maxElement; i++ {
 		possibilities.Add(i)
 	}
@@ -177,12 +338,12 @@ func allPossibilities(baseSize int) utils.Set[int] {
 func (puzzle *Puzzle) assignValuesForSinglePossibilities() bool {
 	valueAssigned := false
 
-	for i, cell := range puzzle.underlyingGrid.cells {
-		if cell.isEmpty() {
+	for i := range puzzle.underlyingGrid.numCells() {
+		if puzzle.underlyingGrid.isEmptyAt(i) {
 			possibilitiesForCell := puzzle.possibleValues[i]
 			if possibilitiesForCell.Size() == 1 {
 				possibility := possibilitiesForCell.Elements()[0]
-				cell.value = &possibility
+				puzzle.underlyingGrid.setValueAt(i, possibility)
 				valueAssigned = true
 			}
 		}
@@ -200,21 +361,18 @@ func (puzzle *Puzzle) eliminatePossibilitiesByRules() bool {
 
 	// continue looping until we can no longer eliminate any possibilities
 	for {
-		for i, cell := range puzzle.underlyingGrid.cells {
+		for i := range puzzle.underlyingGrid.numCells() {
 			// skip cells that already have values
-			if !cell.isEmpty() {
+			if !puzzle.underlyingGrid.isEmptyAt(i) {
 				continue
 			}
 
 			possibilitiesForCell := &puzzle.possibleValues[i]
 
-			peerSet := cell.AllPeers()
-			peers := peerSet.Elements()
-
 			// TODO - nested loop here - possible source of inefficiency?
-			for _, peer := range peers {
-				if peer != nil && !peer.isEmpty() {
-					deletionMade := possibilitiesForCell.Delete(*peer.value)
+			for _, peerIndex := range puzzle.peerIndexes[i] {
+				if !puzzle.underlyingGrid.isEmptyAt(peerIndex) {
+					deletionMade := possibilitiesForCell.Delete(puzzle.underlyingGrid.valueAt(peerIndex))
 					if deletionMade {
 						eliminationsMadeInLoop = true
 						eliminationsMadeInMethod = true
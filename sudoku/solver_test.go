@@ -28,3 +28,47 @@ func TestSolveWithBasicStrategies(t *testing.T) {
 		assert.EqualValues(t, expectedSolution, computedSolution.String())
 	})
 }
+
+func TestSolveWithBacktracking(t *testing.T) {
+	t.Run("Solving a 9x9 challenge that can't be solved with basic strategies alone", func(t *testing.T) {
+		challenge := "4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......"
+		expectedSolution := "417369825632158947958724316825437169791586432346912758289643571573291684164875293"
+
+		initialGrid := sudoku.ParseSingleGrid(challenge)
+		computedSolution := sudoku.SolveWithBacktracking(initialGrid)
+
+		assert.EqualValues(t, expectedSolution, computedSolution.String())
+	})
+}
+
+func TestCountSolutions(t *testing.T) {
+	t.Run("A challenge with a unique solution", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("1......4..2..3..")
+
+		assert.EqualValues(t, 1, sudoku.CountSolutions(grid, 10))
+		assert.True(t, sudoku.HasUniqueSolution(grid))
+	})
+
+	t.Run("An empty 4x4 grid has 288 solutions", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("................")
+
+		assert.EqualValues(t, 288, sudoku.CountSolutions(grid, 1000))
+		assert.EqualValues(t, 5, sudoku.CountSolutions(grid, 5))
+		assert.False(t, sudoku.HasUniqueSolution(grid))
+	})
+
+	t.Run("A grid with conflicting givens has no solutions", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("11..............")
+
+		assert.EqualValues(t, 0, sudoku.CountSolutions(grid, 10))
+		assert.False(t, sudoku.HasUniqueSolution(grid))
+	})
+
+	t.Run("Counting doesn't modify the grid", func(t *testing.T) {
+		challenge := "1......4..2..3.."
+		grid := sudoku.ParseSingleGrid(challenge)
+		sudoku.CountSolutions(grid, 10)
+
+		assert.EqualValues(t, challenge, grid.String())
+	})
+}
@@ -0,0 +1,322 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/DylanSp/sudoku-toolkit/sat"
+	"github.com/samber/lo"
+)
+
+// a common interface for the different solving algorithms, so callers can switch between them
+// use NewSolver() to pick one by name (for instance, from a config file)
+type Solver interface {
+	// solves grid, finding up to the configured maximum number of solutions
+	// returns ErrNoSolution if grid has no solution
+	// if ctx is done (or the time limit runs out) before the solver finishes, returns the partial result so far,
+	// with an error wrapping ctx's error (context.Canceled or context.DeadlineExceeded)
+	// does not modify grid
+	Solve(ctx context.Context, grid Grid) (Result, error)
+}
+
+// options for configuring a solver; each solver ignores the options that don't apply to it
+type SolverOptions struct {
+	// stop once this many solutions have been found; 0 means 1
+	// set it to 2 to check if a grid has a unique solution
+	// the strategy solver only ever finds a single solution
+	MaxSolutions int
+
+	// give up after this long; 0 means no time limit (other than any deadline on the context passed to Solve())
+	TimeLimit time.Duration
+
+	// techniques the strategy solver is allowed to use; nil means all of them
+	AllowedStrategies []Technique
+
+	// how the backtracking solver picks what to branch on
+	Heuristic Heuristic
+
+	// what order the backtracking solver tries a cell's possible values in
+	ValueOrder ValueOrder
+
+	// seed for heuristics and value orders that make random choices; solving with the same seed always makes the same choices
+	Seed uint64
+
+	// how many goroutines the backtracking solver splits its search across; 0 or 1 means it only searches on the calling goroutine
+	// searching in parallel finds solutions in an unpredictable order, so with MaxSolutions > 1, which solutions are returned can vary
+	Parallelism int
+
+	// if non-nil, called with each event as it happens while solving (currently only the backtracking and strategy solvers have events)
+	Observer Observer
+}
+
+// the result of solving a grid with a Solver
+type Result struct {
+	// solutions found, in the order the solver found them; never more than the configured maximum
+	// if there are fewer than the maximum (and Solve() didn't return an error), these are all of the grid's solutions
+	Solutions []Grid
+
+	// how far the solver got: the first solution if it found one,
+	// otherwise the givens plus any values the solver deduced before giving up
+	// (the backtracking solver reports the most complete consistent grid it reached during its search;
+	// the DLX and SAT solvers don't keep partial assignments around, so they only report the givens)
+	Progress Grid
+
+	// the candidates for the empty cells of Progress
+	// the strategy solver reports the candidates it had left when it stopped, after all of its eliminations,
+	// so a solve it got stuck on can be saved (see CandidateGrid.PencilMarkString()) or picked up by another tool;
+	// the other solvers only report the candidates allowed by Progress's values, as computed by NewCandidateGrid()
+	Candidates CandidateGrid
+
+	Stats Stats
+}
+
+// returned when a solver can't find any solution for a grid
+var ErrNoSolution = errors.New("grid has no solution")
+
+// returned by the strategy solver when none of the allowed techniques can make any more progress
+var ErrStrategiesExhausted = errors.New("no further progress can be made with the allowed strategies")
+
+// solves like a human would, using logical techniques without any guessing
+// since every technique only makes deductions that hold in every solution, a grid solved this way has exactly one solution
+type StrategySolver struct {
+	Options SolverOptions
+}
+
+// solves with recursive backtracking search, applying basic strategies at each step to narrow down possibilities
+type BacktrackingSolver struct {
+	Options SolverOptions
+}
+
+// solves by encoding the grid as an exact cover problem and using Dancing Links (Algorithm X)
+type DLXSolver struct {
+	Options SolverOptions
+}
+
+// solves by encoding the grid as CNF (with the extended encoding) and running the built-in CDCL SAT solver
+type SATSolver struct {
+	Options SolverOptions
+}
+
+// compile-time checks that each solver implements the interface
+var (
+	_ Solver = StrategySolver{}
+	_ Solver = BacktrackingSolver{}
+	_ Solver = DLXSolver{}
+	_ Solver = SATSolver{}
+)
+
+// constructors for each solver that can be picked with NewSolver(), keyed by name
+var solverConstructors = map[string]func(options SolverOptions) Solver{
+	"strategies":   func(options SolverOptions) Solver { return StrategySolver{Options: options} },
+	"backtracking": func(options SolverOptions) Solver { return BacktrackingSolver{Options: options} },
+	"dlx":          func(options SolverOptions) Solver { return DLXSolver{Options: options} },
+	"sat":          func(options SolverOptions) Solver { return SATSolver{Options: options} },
+}
+
+// creates the solver with the given name, configured with options
+// returns an error if there's no solver with that name; see SolverNames() for the valid names
+func NewSolver(name string, options SolverOptions) (Solver, error) {
+	constructor, ok := solverConstructors[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown solver %q; valid solvers are %v", name, SolverNames())
+	}
+
+	return constructor(options), nil
+}
+
+// names of all solvers that can be created with NewSolver(), in alphabetical order
+func SolverNames() []string {
+	names := lo.Keys(solverConstructors)
+	slices.Sort(names)
+	return names
+}
+
+// the maximum number of solutions to find, with the default applied
+func (options SolverOptions) maxSolutions() int {
+	if options.MaxSolutions < 1 {
+		return 1
+	}
+
+	return options.MaxSolutions
+}
+
+// derives a context that's cancelled once the time limit (if any) runs out
+func (options SolverOptions) withTimeLimit(ctx context.Context) (context.Context, context.CancelFunc) {
+	if options.TimeLimit <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, options.TimeLimit)
+}
+
+// wraps the error from a context that's done, so callers can tell that the solver stopped early (and why) with errors.Is()
+func stoppedEarlyError(ctx context.Context, result Result) error {
+	return fmt.Errorf("solving stopped early, after finding %v solution(s): %w", len(result.Solutions), ctx.Err())
+}
+
+// fills in result.Progress from the solutions found (if any), result.Candidates (if the solver didn't), and the wall time since startTime,
+// and returns the appropriate error for a finished search
+// a search that was stopped by its context returns an error wrapping the context's error, even if it found some solutions
+func finishResult(ctx context.Context, result Result, startTime time.Time) (Result, error) {
+	result.Stats.WallTime = time.Since(startTime)
+
+	if len(result.Solutions) > 0 {
+		result.Progress = result.Solutions[0]
+	}
+	if result.Candidates.puzzle.possibleValues == nil {
+		result.Candidates = NewCandidateGrid(result.Progress)
+	}
+
+	if ctx.Err() != nil {
+		return result, stoppedEarlyError(ctx, result)
+	}
+
+	if len(result.Solutions) == 0 {
+		return result, ErrNoSolution
+	}
+
+	return result, nil
+}
+
+func (solver StrategySolver) Solve(ctx context.Context, grid Grid) (Result, error) {
+	startTime := time.Now()
+	ctx, cancel := solver.Options.withTimeLimit(ctx)
+	defer cancel()
+
+	techniques := allTechniques
+	if solver.Options.AllowedStrategies != nil {
+		techniques = slices.Clone(solver.Options.AllowedStrategies)
+		for _, technique := range techniques {
+			if !slices.Contains(allTechniques, technique) {
+				return Result{}, fmt.Errorf("unknown strategy %d", technique)
+			}
+		}
+
+		// techniques are ordered by difficulty, so this makes sure easier techniques are always tried first
+		slices.Sort(techniques)
+	}
+
+	puzzle := newPuzzle(grid.Clone())
+	recorder := solveRecorder{observer: solver.Options.Observer}
+
+	outcome := puzzle.applyTechniques(ctx, techniques, &recorder)
+	candidates := CandidateGrid{puzzle: puzzle}
+
+	switch outcome {
+	case techniquesSolved:
+		return finishResult(ctx, Result{Solutions: []Grid{puzzle.underlyingGrid.grid()}, Candidates: candidates, Stats: recorder.stats}, startTime)
+	case techniquesContradiction, techniquesCancelled:
+		return finishResult(ctx, Result{Progress: puzzle.underlyingGrid.grid(), Candidates: candidates, Stats: recorder.stats}, startTime)
+	default:
+		recorder.stats.WallTime = time.Since(startTime)
+		return Result{Progress: puzzle.underlyingGrid.grid(), Candidates: candidates, Stats: recorder.stats}, ErrStrategiesExhausted
+	}
+}
+
+func (solver BacktrackingSolver) Solve(ctx context.Context, grid Grid) (Result, error) {
+	startTime := time.Now()
+	ctx, cancel := solver.Options.withTimeLimit(ctx)
+	defer cancel()
+
+	if !slices.Contains(allHeuristics, solver.Options.Heuristic) {
+		return Result{}, fmt.Errorf("unknown heuristic %d", solver.Options.Heuristic)
+	}
+	if !slices.Contains(allValueOrders, solver.Options.ValueOrder) {
+		return Result{}, fmt.Errorf("unknown value order %d", solver.Options.ValueOrder)
+	}
+
+	result := Result{Progress: grid.Clone()}
+	search := backtrackingSearch{
+		ctx:          ctx,
+		maxSolutions: solver.Options.maxSolutions(),
+		heuristic:    solver.Options.Heuristic,
+		valueOrder:   solver.Options.ValueOrder,
+		rng:          newRandFromSeed(solver.Options.Seed),
+		recorder:     solveRecorder{observer: solver.Options.Observer},
+		onSolution: func(solution Puzzle) {
+			result.Solutions = append(result.Solutions, solution.underlyingGrid.grid())
+		},
+	}
+
+	if solver.Options.Parallelism > 1 {
+		newWorkerRand := func(worker int) *rand.Rand {
+			return newRandFromSeed(solver.Options.Seed + uint64(worker))
+		}
+
+		parallelResult := runParallelSearch(ctx, newPuzzle(grid.Clone()), search, solver.Options.Parallelism, newWorkerRand)
+		for _, solution := range parallelResult.solutions {
+			result.Solutions = append(result.Solutions, solution.underlyingGrid.grid())
+		}
+		search.recorder.stats = parallelResult.stats
+		search.mostFilledGrid, search.mostFilledCellCount = parallelResult.mostFilledGrid, parallelResult.mostFilledCellCount
+	} else {
+		search.run(newPuzzle(grid.Clone()), 0)
+	}
+
+	result.Stats = search.recorder.stats
+	if search.mostFilledCellCount > 0 {
+		result.Progress = search.mostFilledGrid
+	}
+
+	return finishResult(ctx, result, startTime)
+}
+
+func (solver DLXSolver) Solve(ctx context.Context, grid Grid) (Result, error) {
+	startTime := time.Now()
+	ctx, cancel := solver.Options.withTimeLimit(ctx)
+	defer cancel()
+
+	result := Result{Progress: grid.Clone()}
+	problem, choices := gridToExactCover(grid)
+	maxSolutions := solver.Options.maxSolutions()
+
+	// any error is ctx's error, which finishResult() handles
+	_ = problem.SolveContext(ctx, func(rows []int) bool {
+		result.Solutions = append(result.Solutions, exactCoverSolutionToGrid(grid, choices, rows))
+		return len(result.Solutions) >= maxSolutions
+	})
+
+	return finishResult(ctx, result, startTime)
+}
+
+func (solver SATSolver) Solve(ctx context.Context, grid Grid) (Result, error) {
+	startTime := time.Now()
+	ctx, cancel := solver.Options.withTimeLimit(ctx)
+	defer cancel()
+
+	result := Result{Progress: grid.Clone()}
+	cnf := ToCNF(grid, CNFOptions{Encoding: ExtendedEncoding})
+	maxSolutions := solver.Options.maxSolutions()
+
+	for len(result.Solutions) < maxSolutions {
+		model, err := sat.SolveContext(ctx, &cnf)
+		if errors.Is(err, sat.ErrUnsatisfiable) || ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+
+		solution, err := FromSATModel(grid, model)
+		if err != nil {
+			return result, err
+		}
+		result.Solutions = append(result.Solutions, solution)
+
+		// block this solution, so the next call has to find a different one:
+		// at least one of the cell values that are true in this solution must be false
+		blockingClause := []sat.Literal{}
+		for _, literal := range model {
+			if literal > 0 {
+				blockingClause = append(blockingClause, literal.Negated())
+			}
+		}
+		cnf.AddClause(blockingClause...)
+	}
+
+	return finishResult(ctx, result, startTime)
+}
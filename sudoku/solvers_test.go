@@ -0,0 +1,341 @@
+package sudoku_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+// solvers that search the whole solution space, so they can solve any grid and count its solutions
+var searchSolverNames = []string{"backtracking", "dlx", "sat"}
+
+func newSolver(t testing.TB, name string, options sudoku.SolverOptions) sudoku.Solver {
+	t.Helper()
+
+	solver, err := sudoku.NewSolver(name, options)
+	assert.NoError(t, err)
+
+	return solver
+}
+
+// counts a grid's solutions (up to maxSolutions) with the named solver
+func countSolutions(t testing.TB, name string, grid sudoku.Grid, maxSolutions int) int {
+	t.Helper()
+
+	result, err := newSolver(t, name, sudoku.SolverOptions{MaxSolutions: maxSolutions}).Solve(context.Background(), grid)
+	if err != nil {
+		assert.ErrorIs(t, err, sudoku.ErrNoSolution)
+	}
+
+	return len(result.Solutions)
+}
+
+// TODO - figure out a less brittle way get the path to the example files? maybe copy them to a subfolder in this directory?
+func loadExampleGrids(t testing.TB, sizeFolder string, exampleFile string) []sudoku.Grid {
+	t.Helper()
+
+	currentWorkingDir, err := os.Getwd()
+	assert.NoError(t, err)
+
+	grids, err := sudoku.LoadGridsFromFile(filepath.Join(currentWorkingDir, "..", "examples", sizeFolder, exampleFile))
+	assert.NoError(t, err)
+
+	return grids
+}
+
+func TestSolvers(t *testing.T) {
+	for _, name := range searchSolverNames {
+		t.Run(name, func(t *testing.T) {
+			t.Run("Solving 9x9 challenges", func(t *testing.T) {
+				solver := newSolver(t, name, sudoku.SolverOptions{})
+
+				for _, exampleFile := range []string{"easy50.txt", "hardest.txt"} {
+					for _, challenge := range loadExampleGrids(t, "9x9", exampleFile) {
+						originalChallenge := challenge.String()
+
+						result, err := solver.Solve(context.Background(), challenge)
+						assert.NoError(t, err)
+						assert.Len(t, result.Solutions, 1)
+						assert.True(t, sudoku.IsSolutionOf(challenge, result.Solutions[0]))
+						assert.EqualValues(t, result.Solutions[0].String(), result.Progress.String())
+
+						// solving doesn't modify the challenge
+						assert.EqualValues(t, originalChallenge, challenge.String())
+					}
+				}
+			})
+
+			t.Run("Solving challenges with non-square boxes", func(t *testing.T) {
+				// the default backtracking heuristic is far too slow on larger grids, so use a smarter one
+				solver := newSolver(t, name, sudoku.SolverOptions{MaxSolutions: 2, Heuristic: sudoku.HouseBranching})
+
+				for _, sizeFolder := range []string{"6x6", "8x8", "10x10", "12x12"} {
+					for _, challenge := range loadExampleGrids(t, sizeFolder, "challenges.txt") {
+						result, err := solver.Solve(context.Background(), challenge)
+						assert.NoError(t, err)
+						assert.Len(t, result.Solutions, 1)
+						assert.True(t, result.Solutions[0].IsValidSolution())
+						assert.EqualValues(t, challenge.BoxWidth(), result.Solutions[0].BoxWidth())
+						assert.EqualValues(t, challenge.BoxHeight(), result.Solutions[0].BoxHeight())
+					}
+				}
+			})
+
+			t.Run("Solving and counting Latin squares", func(t *testing.T) {
+				assert.EqualValues(t, 12, countSolutions(t, name, sudoku.EmptyLatinSquare(3), 1000))
+				assert.EqualValues(t, 576, countSolutions(t, name, sudoku.EmptyLatinSquare(4), 1000))
+
+				// the 4x4 Sudoku challenge has more than one solution once its boxes are gone
+				assert.EqualValues(t, 1, countSolutions(t, name, sudoku.ParseSingleGrid("1......4..2..3.."), 10))
+				assert.Greater(t, countSolutions(t, name, sudoku.ParseLatinSquare("1......4..2..3.."), 10), 1)
+
+				result, err := newSolver(t, name, sudoku.SolverOptions{}).Solve(context.Background(), sudoku.ParseLatinSquare("1.3..3.1.1.3..1."))
+				assert.NoError(t, err)
+				assert.True(t, result.Solutions[0].IsValidSolution())
+				assert.False(t, result.Solutions[0].HasBoxes())
+			})
+
+			t.Run("Counting solutions", func(t *testing.T) {
+				assert.EqualValues(t, 288, countSolutions(t, name, sudoku.EmptyGrid(2), 1000))
+				assert.EqualValues(t, 10, countSolutions(t, name, sudoku.EmptyGrid(2), 10))
+				assert.EqualValues(t, 1, countSolutions(t, name, sudoku.ParseSingleGrid("1......4..2..3.."), 10))
+			})
+
+			t.Run("A grid with conflicting givens has no solution", func(t *testing.T) {
+				grid := sudoku.ParseSingleGrid("11..............")
+
+				result, err := newSolver(t, name, sudoku.SolverOptions{}).Solve(context.Background(), grid)
+				assert.ErrorIs(t, err, sudoku.ErrNoSolution)
+				assert.Empty(t, result.Solutions)
+			})
+
+			t.Run("A cancelled context stops the solver", func(t *testing.T) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				grid := sudoku.EmptyGrid(3)
+				result, err := newSolver(t, name, sudoku.SolverOptions{}).Solve(ctx, grid)
+				assert.ErrorIs(t, err, context.Canceled)
+				assert.Empty(t, result.Solutions)
+				assert.EqualValues(t, grid.String(), result.Progress.String())
+			})
+
+			t.Run("Running out of time returns the solutions found so far", func(t *testing.T) {
+				// an empty 9x9 grid has billions of solutions, so there's no way to find them all in time
+				options := sudoku.SolverOptions{
+					MaxSolutions: 1_000_000_000,
+					TimeLimit:    50 * time.Millisecond,
+				}
+
+				grid := sudoku.EmptyGrid(3)
+				startTime := time.Now()
+				result, err := newSolver(t, name, options).Solve(context.Background(), grid)
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				assert.Less(t, time.Since(startTime), 5*time.Second)
+
+				// how many solutions are found in time depends on the machine, so there might not be any
+				for _, solution := range result.Solutions {
+					assert.True(t, solution.IsValidSolution())
+				}
+				if len(result.Solutions) > 0 {
+					assert.True(t, result.Progress.Equal(result.Solutions[0]))
+				} else {
+					// partial progress, which may or may not have any values filled in
+					assert.True(t, sudoku.IsConsistent(result.Progress))
+				}
+			})
+		})
+	}
+
+	t.Run("strategies", func(t *testing.T) {
+		t.Run("Solving easy 9x9 challenges", func(t *testing.T) {
+			solver := newSolver(t, "strategies", sudoku.SolverOptions{})
+
+			for _, challenge := range loadExampleGrids(t, "9x9", "easy50.txt") {
+				result, err := solver.Solve(context.Background(), challenge)
+				assert.NoError(t, err)
+				assert.Len(t, result.Solutions, 1)
+				assert.True(t, result.Solutions[0].IsValidSolution())
+			}
+		})
+
+		t.Run("Getting stuck returns partial progress", func(t *testing.T) {
+			// needs an X-Wing, so singles alone aren't enough
+			challenge := sudoku.ParseSingleGrid(".43.8.25.6.............1.949....4.7....6.8....1.2....382.5.............5.34.9.71.")
+
+			options := sudoku.SolverOptions{
+				AllowedStrategies: []sudoku.Technique{sudoku.HiddenSingle, sudoku.NakedSingle},
+			}
+			result, err := newSolver(t, "strategies", options).Solve(context.Background(), challenge)
+			assert.ErrorIs(t, err, sudoku.ErrStrategiesExhausted)
+			assert.Empty(t, result.Solutions)
+
+			// some values were filled in, but not all of them
+			remainingEmptyCells := strings.Count(result.Progress.String(), ".")
+			assert.Positive(t, remainingEmptyCells)
+			assert.Less(t, remainingEmptyCells, strings.Count(challenge.String(), "."))
+
+			// the candidates it was left with can be saved and read back
+			assert.True(t, result.Candidates.Grid().Equal(result.Progress))
+			assert.Empty(t, result.Candidates.StaleCandidates())
+			saved, err := sudoku.ParsePencilMarkGrid(result.Candidates.PencilMarkString())
+			assert.NoError(t, err)
+			assert.True(t, saved.Equal(result.Candidates))
+
+			// with every technique allowed, it can be solved
+			result, err = newSolver(t, "strategies", sudoku.SolverOptions{}).Solve(context.Background(), challenge)
+			assert.NoError(t, err)
+			assert.True(t, result.Solutions[0].IsValidSolution())
+			incorrect, err := saved.IncorrectEliminations(result.Solutions[0])
+			assert.NoError(t, err)
+			assert.Empty(t, incorrect)
+		})
+
+		t.Run("A cancelled context stops the solver", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			challenge := sudoku.ParseSingleGrid(".43.8.25.6.............1.949....4.7....6.8....1.2....382.5.............5.34.9.71.")
+			result, err := newSolver(t, "strategies", sudoku.SolverOptions{}).Solve(ctx, challenge)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.EqualValues(t, challenge.String(), result.Progress.String())
+		})
+
+		t.Run("A grid with conflicting givens has no solution", func(t *testing.T) {
+			_, err := newSolver(t, "strategies", sudoku.SolverOptions{}).Solve(context.Background(), sudoku.ParseSingleGrid("11.............."))
+			assert.ErrorIs(t, err, sudoku.ErrNoSolution)
+		})
+	})
+
+	t.Run("Cancelling the backtracking solver after a solution returns it", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// cancel as soon as the first solution is found, so the result doesn't depend on how fast the machine is
+		options := sudoku.SolverOptions{
+			MaxSolutions: 1_000_000_000,
+			Observer: func(event sudoku.Event) {
+				if event.Kind == sudoku.SolutionEvent {
+					cancel()
+				}
+			},
+		}
+
+		result, err := newSolver(t, "backtracking", options).Solve(ctx, sudoku.EmptyGrid(3))
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotEmpty(t, result.Solutions)
+		for _, solution := range result.Solutions {
+			assert.True(t, solution.IsValidSolution())
+		}
+		assert.True(t, result.Progress.Equal(result.Solutions[0]))
+	})
+
+	t.Run("Random candidate heuristic", func(t *testing.T) {
+		solutionForSeed := func(seed uint64) string {
+			options := sudoku.SolverOptions{
+				Heuristic: sudoku.RandomCandidate,
+				Seed:      seed,
+			}
+
+			result, err := newSolver(t, "backtracking", options).Solve(context.Background(), sudoku.EmptyGrid(3))
+			assert.NoError(t, err)
+			assert.True(t, result.Solutions[0].IsValidSolution())
+
+			return result.Solutions[0].String()
+		}
+
+		assert.EqualValues(t, solutionForSeed(1), solutionForSeed(1))
+		assert.NotEqualValues(t, solutionForSeed(1), solutionForSeed(2))
+	})
+}
+
+func TestNewSolver(t *testing.T) {
+	t.Run("Every listed solver can be created", func(t *testing.T) {
+		assert.EqualValues(t, []string{"backtracking", "dlx", "sat", "strategies"}, sudoku.SolverNames())
+
+		for _, name := range sudoku.SolverNames() {
+			solver, err := sudoku.NewSolver(name, sudoku.SolverOptions{})
+			assert.NoError(t, err)
+			assert.NotNil(t, solver)
+		}
+	})
+
+	t.Run("Unknown names return an error", func(t *testing.T) {
+		_, err := sudoku.NewSolver("quantum", sudoku.SolverOptions{})
+		assert.Error(t, err)
+	})
+}
+
+// compares solvers on the hard 9x9 challenges
+// note that a single pass of the backtracking solver over hard95.txt currently takes several minutes,
+// so use something like `-bench "Solvers/(dlx|sat)"` to benchmark only the faster solvers
+func BenchmarkSolvers(b *testing.B) {
+	challenges := loadExampleGrids(b, "9x9", "hard95.txt")
+
+	for _, name := range searchSolverNames {
+		solver := newSolver(b, name, sudoku.SolverOptions{})
+
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, challenge := range challenges {
+					_, err := solver.Solve(context.Background(), challenge)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// checks that every solver agrees on how many solutions a 4x4 grid has, and that their solutions are valid and keep the givens
+func FuzzSolversAgree(f *testing.F) {
+	f.Add("1......4..2..3..")
+	f.Add("................")
+	f.Add("1432321441232341")
+	f.Add("11..............")
+	f.Add("12..34..........")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		// only use inputs that parse as a 4x4 grid, with one character per cell
+		if len(input) != 16 {
+			t.Skip()
+		}
+		for _, ch := range input {
+			if !strings.ContainsRune("1234.", ch) {
+				t.Skip()
+			}
+		}
+
+		grid := sudoku.ParseSingleGrid(input)
+
+		// an empty 4x4 grid has 288 solutions, so this limit lets every solver count everything
+		const maxSolutions = 300
+		expectedCount := countSolutions(t, "dlx", grid, maxSolutions)
+
+		for _, name := range searchSolverNames {
+			assert.EqualValues(t, expectedCount, countSolutions(t, name, grid, maxSolutions), name)
+
+			result, err := newSolver(t, name, sudoku.SolverOptions{}).Solve(context.Background(), grid)
+			if expectedCount == 0 {
+				assert.ErrorIs(t, err, sudoku.ErrNoSolution, name)
+				continue
+			}
+
+			assert.NoError(t, err, name)
+			solution := result.Solutions[0]
+			assert.True(t, solution.IsValidSolution(), name)
+			for i, ch := range input {
+				if ch != '.' {
+					assert.EqualValues(t, ch, solution.String()[i], name)
+				}
+			}
+		}
+	})
+}
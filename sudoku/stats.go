@@ -0,0 +1,151 @@
+package sudoku
+
+import (
+	"time"
+)
+
+// counters describing how much work a solver did, for comparing solvers and their options
+// the search counters are only filled in by the backtracking solver,
+// and the propagation/elimination counters by the backtracking and strategy solvers
+type Stats struct {
+	// number of nodes in the search tree that were explored
+	Nodes int
+
+	// number of dead ends hit during the search, each of which made the search back up and try another branch
+	Backtracks int
+
+	// the deepest level of the search tree that was reached; the root (before any branching) is depth 0
+	MaxDepth int
+
+	// number of passes applying the basic rules, which eliminate possibilities that a peer already has as its value
+	PropagationPasses int
+
+	// possibilities eliminated by the basic rules, over all propagation passes
+	RuleEliminations int
+
+	// possibilities eliminated by each technique that made progress
+	// singles place values rather than eliminating possibilities, so for them this counts values placed
+	Eliminations map[Technique]int
+
+	WallTime time.Duration
+}
+
+// adds the counters from other to stats, as if both sets of work had been done by one solver
+// wall times aren't added, since work done in parallel overlaps
+func (stats *Stats) add(other Stats) {
+	stats.Nodes += other.Nodes
+	stats.Backtracks += other.Backtracks
+	stats.MaxDepth = max(stats.MaxDepth, other.MaxDepth)
+	stats.PropagationPasses += other.PropagationPasses
+	stats.RuleEliminations += other.RuleEliminations
+
+	for technique, eliminations := range other.Eliminations {
+		if stats.Eliminations == nil {
+			stats.Eliminations = map[Technique]int{}
+		}
+		stats.Eliminations[technique] += eliminations
+	}
+}
+
+// what happened, for an event passed to an Observer
+type EventKind int
+
+const (
+	_              EventKind = iota // zero value means "no event kind specified"
+	BranchEvent                     // the backtracking solver is trying Value for the cell at CellIndex, at Depth
+	BacktrackEvent                  // the backtracking solver hit a dead end at Depth
+	SolutionEvent                   // the backtracking solver found a solution at Depth
+	TechniqueEvent                  // Technique made progress, making Eliminations eliminations (see Stats.Eliminations)
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case BranchEvent:
+		return "Branch"
+	case BacktrackEvent:
+		return "Backtrack"
+	case SolutionEvent:
+		return "Solution"
+	case TechniqueEvent:
+		return "Technique"
+	default:
+		return "unspecified"
+	}
+}
+
+// something that happened while solving; which fields are meaningful depends on Kind
+type Event struct {
+	Kind EventKind
+
+	Depth     int
+	CellIndex int
+	Value     int
+
+	Technique    Technique
+	Eliminations int
+}
+
+// called with each event as it happens, to stream a solver's progress
+// observers are called synchronously by the solver, so slow observers slow down solving
+// when the backtracking solver searches in parallel, the observer is called from several goroutines at once
+type Observer func(event Event)
+
+// collects stats while solving, passing events on to an observer (if there is one)
+// the zero value is ready to use, and has no observer
+type solveRecorder struct {
+	stats    Stats
+	observer Observer
+}
+
+func (recorder *solveRecorder) emit(event Event) {
+	if recorder.observer != nil {
+		recorder.observer(event)
+	}
+}
+
+func (recorder *solveRecorder) nodeVisited(depth int) {
+	recorder.stats.Nodes++
+	recorder.stats.MaxDepth = max(recorder.stats.MaxDepth, depth)
+}
+
+func (recorder *solveRecorder) branched(depth int, cellIndex int, value int) {
+	recorder.emit(Event{
+		Kind:      BranchEvent,
+		Depth:     depth,
+		CellIndex: cellIndex,
+		Value:     value,
+	})
+}
+
+func (recorder *solveRecorder) backtracked(depth int) {
+	recorder.stats.Backtracks++
+	recorder.emit(Event{
+		Kind:  BacktrackEvent,
+		Depth: depth,
+	})
+}
+
+func (recorder *solveRecorder) solutionFound(depth int) {
+	recorder.emit(Event{
+		Kind:  SolutionEvent,
+		Depth: depth,
+	})
+}
+
+func (recorder *solveRecorder) propagated(eliminations int) {
+	recorder.stats.PropagationPasses++
+	recorder.stats.RuleEliminations += eliminations
+}
+
+func (recorder *solveRecorder) techniqueApplied(technique Technique, eliminations int) {
+	if recorder.stats.Eliminations == nil {
+		recorder.stats.Eliminations = map[Technique]int{}
+	}
+	recorder.stats.Eliminations[technique] += eliminations
+
+	recorder.emit(Event{
+		Kind:         TechniqueEvent,
+		Technique:    technique,
+		Eliminations: eliminations,
+	})
+}
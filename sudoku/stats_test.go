@@ -0,0 +1,90 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	t.Run("Backtracking search stats", func(t *testing.T) {
+		challenge := loadExampleGrids(t, "9x9", "hardest.txt")[0]
+
+		result, err := sudoku.BacktrackingSolver{}.Solve(context.Background(), challenge)
+		assert.NoError(t, err)
+
+		stats := result.Stats
+		assert.Greater(t, stats.Nodes, 1)
+		assert.Positive(t, stats.Backtracks)
+		assert.Less(t, stats.Backtracks, stats.Nodes)
+		assert.Positive(t, stats.MaxDepth)
+		assert.Less(t, stats.MaxDepth, stats.Nodes)
+
+		// every node applies the basic rules at least once
+		assert.GreaterOrEqual(t, stats.PropagationPasses, stats.Nodes)
+		assert.Positive(t, stats.RuleEliminations)
+		assert.Positive(t, stats.Eliminations[sudoku.NakedSingle])
+
+		assert.Positive(t, stats.WallTime)
+	})
+
+	t.Run("The observer sees every branch, backtrack and solution", func(t *testing.T) {
+		eventCounts := map[sudoku.EventKind]int{}
+		options := sudoku.SolverOptions{
+			MaxSolutions: 1000,
+			Observer: func(event sudoku.Event) {
+				eventCounts[event.Kind]++
+			},
+		}
+
+		result, err := sudoku.BacktrackingSolver{Options: options}.Solve(context.Background(), sudoku.EmptyGrid(2))
+		assert.NoError(t, err)
+		assert.Len(t, result.Solutions, 288)
+
+		// every node except the root is reached by a branch
+		assert.EqualValues(t, result.Stats.Nodes-1, eventCounts[sudoku.BranchEvent])
+		assert.EqualValues(t, result.Stats.Backtracks, eventCounts[sudoku.BacktrackEvent])
+		assert.EqualValues(t, 288, eventCounts[sudoku.SolutionEvent])
+		assert.Len(t, result.Stats.Eliminations, 1) // only naked singles are used during search
+		assert.Positive(t, eventCounts[sudoku.TechniqueEvent])
+	})
+
+	t.Run("Strategy solver stats match the challenge's rating", func(t *testing.T) {
+		// needs an X-Wing, along with several easier techniques
+		challenge := sudoku.ParseSingleGrid(".43.8.25.6.............1.949....4.7....6.8....1.2....382.5.............5.34.9.71.")
+		rating := sudoku.Rate(challenge)
+
+		techniqueEvents := map[sudoku.Technique]int{}
+		options := sudoku.SolverOptions{
+			Observer: func(event sudoku.Event) {
+				assert.EqualValues(t, sudoku.TechniqueEvent, event.Kind)
+				assert.Positive(t, event.Eliminations)
+				techniqueEvents[event.Technique]++
+			},
+		}
+
+		result, err := sudoku.StrategySolver{Options: options}.Solve(context.Background(), challenge)
+		assert.NoError(t, err)
+		assert.EqualValues(t, rating.TechniqueCounts, techniqueEvents)
+
+		for technique := range rating.TechniqueCounts {
+			assert.GreaterOrEqual(t, result.Stats.Eliminations[technique], rating.TechniqueCounts[technique], technique.String())
+		}
+
+		// no search happens
+		assert.Zero(t, result.Stats.Nodes)
+		assert.Positive(t, result.Stats.PropagationPasses)
+	})
+
+	t.Run("Every solver reports wall time", func(t *testing.T) {
+		challenge := loadExampleGrids(t, "9x9", "easy50.txt")[0]
+
+		for _, name := range sudoku.SolverNames() {
+			result, err := newSolver(t, name, sudoku.SolverOptions{}).Solve(context.Background(), challenge)
+			assert.NoError(t, err, name)
+			assert.Positive(t, result.Stats.WallTime, name)
+		}
+	})
+}
@@ -0,0 +1,519 @@
+package sudoku
+
+import (
+	"context"
+	"slices"
+
+	"github.com/DylanSp/sudoku-toolkit/utils"
+)
+
+// a human-style solving technique, used for rating how difficult a challenge is
+// techniques are ordered from easiest to hardest, so they can be compared with < and >
+type Technique int
+
+const (
+	_ Technique = iota // zero value means "no technique specified"
+	NakedSingle
+	HiddenSingle
+	LockedCandidates
+	NakedPair
+	HiddenPair
+	NakedTriple
+	HiddenTriple
+	XWing
+	XYWing
+	Swordfish
+)
+
+// all techniques, from easiest to hardest
+var allTechniques = []Technique{
+	NakedSingle,
+	HiddenSingle,
+	LockedCandidates,
+	NakedPair,
+	HiddenPair,
+	NakedTriple,
+	HiddenTriple,
+	XWing,
+	XYWing,
+	Swordfish,
+}
+
+func (t Technique) String() string {
+	switch t {
+	case NakedSingle:
+		return "Naked Single"
+	case HiddenSingle:
+		return "Hidden Single"
+	case LockedCandidates:
+		return "Locked Candidates"
+	case NakedPair:
+		return "Naked Pair"
+	case HiddenPair:
+		return "Hidden Pair"
+	case NakedTriple:
+		return "Naked Triple"
+	case HiddenTriple:
+		return "Hidden Triple"
+	case XWing:
+		return "X-Wing"
+	case XYWing:
+		return "XY-Wing"
+	case Swordfish:
+		return "Swordfish"
+	default:
+		return "unspecified"
+	}
+}
+
+// which difficulty tier a challenge needing this technique belongs to
+func (t Technique) Difficulty() Difficulty {
+	switch t {
+	case NakedSingle, HiddenSingle:
+		return Easy
+	case LockedCandidates, NakedPair, HiddenPair:
+		return Medium
+	case NakedTriple, HiddenTriple, XWing:
+		return Hard
+	case XYWing, Swordfish:
+		return Expert
+	default:
+		panic("can't find difficulty for unspecified technique")
+	}
+}
+
+// applies the technique everywhere it can currently be used in the puzzle
+// returns true iff any value was assigned or any possibility was eliminated
+func (t Technique) apply(puzzle *Puzzle) bool {
+	switch t {
+	case NakedSingle:
+		return puzzle.assignValuesForSinglePossibilities()
+	case HiddenSingle:
+		return puzzle.applyHiddenSingles()
+	case LockedCandidates:
+		return puzzle.applyLockedCandidates()
+	case NakedPair:
+		return puzzle.applyNakedSubsets(2)
+	case HiddenPair:
+		return puzzle.applyHiddenSubsets(2)
+	case NakedTriple:
+		return puzzle.applyNakedSubsets(3)
+	case HiddenTriple:
+		return puzzle.applyHiddenSubsets(3)
+	case XWing:
+		return puzzle.applyFish(2)
+	case XYWing:
+		return puzzle.applyXYWings()
+	case Swordfish:
+		return puzzle.applyFish(3)
+	default:
+		panic("can't apply unspecified technique")
+	}
+}
+
+// how a run of applyTechniques() ended
+type techniqueOutcome int
+
+const (
+	techniquesSolved        techniqueOutcome = iota // the grid is completely and validly filled
+	techniquesStuck                                 // none of the techniques can make any more progress
+	techniquesContradiction                         // the puzzle can't be solved from its current state
+	techniquesCancelled                             // the context was done before any of the other outcomes was reached
+)
+
+// repeatedly applies the easiest technique (of the ones given) that makes progress, until the puzzle is solved or no more progress can be made
+// after each step, starts over with the easiest technique, so harder techniques are only used when they're really needed
+// techniques must be sorted from easiest to hardest; recorder records every propagation pass and every time a technique makes progress
+// ctx is checked before each step, so the puzzle is always left in a consistent state when it's cancelled
+func (puzzle *Puzzle) applyTechniques(ctx context.Context, techniques []Technique, recorder *solveRecorder) techniqueOutcome {
+	for {
+		if ctx.Err() != nil {
+			return techniquesCancelled
+		}
+
+		possibilitiesBefore := puzzle.possibilityCount()
+		puzzle.eliminatePossibilitiesByRules()
+		recorder.propagated(possibilitiesBefore - puzzle.possibilityCount())
+
+		if puzzle.hasContradiction() {
+			return techniquesContradiction
+		}
+
+		if puzzle.underlyingGrid.isValidSolution() {
+			return techniquesSolved
+		}
+
+		progressMade := false
+		for _, technique := range techniques {
+			possibilitiesBefore, filledCellsBefore := puzzle.possibilityCount(), puzzle.underlyingGrid.filledCellCount()
+
+			if technique.apply(puzzle) {
+				// singles place values instead of eliminating possibilities, so count the values placed for them
+				eliminations := possibilitiesBefore - puzzle.possibilityCount()
+				if technique == NakedSingle || technique == HiddenSingle {
+					eliminations = puzzle.underlyingGrid.filledCellCount() - filledCellsBefore
+				}
+
+				recorder.techniqueApplied(technique, eliminations)
+				progressMade = true
+				break
+			}
+		}
+
+		if !progressMade {
+			return techniquesStuck
+		}
+	}
+}
+
+// total number of possibilities left for all empty cells
+func (puzzle *Puzzle) possibilityCount() int {
+	count := 0
+	for i := range puzzle.possibleValues {
+		if puzzle.isCellEmpty(i) {
+			count += puzzle.possibleValues[i].Size()
+		}
+	}
+
+	return count
+}
+
+// utility method for clarity
+func (puzzle *Puzzle) isCellEmpty(cellIndex int) bool {
+	return puzzle.underlyingGrid.isEmptyAt(cellIndex)
+}
+
+// removes value from the possibilities of the (empty) cell at cellIndex
+// returns true iff value was a possibility beforehand
+func (puzzle *Puzzle) eliminatePossibility(cellIndex int, value int) bool {
+	if !puzzle.isCellEmpty(cellIndex) {
+		return false
+	}
+
+	return puzzle.possibleValues[cellIndex].Delete(value)
+}
+
+// possibilities for the cell at cellIndex, in increasing order
+func (puzzle *Puzzle) sortedPossibilities(cellIndex int) []int {
+	possibilities := puzzle.possibleValues[cellIndex].Elements()
+	slices.Sort(possibilities)
+	return possibilities
+}
+
+// indexes of the empty cells in house that still have value as a possibility
+func (puzzle *Puzzle) cellsWithPossibility(house []int, value int) []int {
+	cells := []int{}
+
+	for _, cellIndex := range house {
+		if puzzle.isCellEmpty(cellIndex) && puzzle.possibleValues[cellIndex].Has(value) {
+			cells = append(cells, cellIndex)
+		}
+	}
+
+	return cells
+}
+
+// checks if value has already been assigned to a cell in house
+func (puzzle *Puzzle) isValuePlacedInHouse(house []int, value int) bool {
+	return slices.ContainsFunc(house, func(cellIndex int) bool {
+		return puzzle.underlyingGrid.valueAt(cellIndex) == value
+	})
+}
+
+// if a value can only go in one cell of a house, assign it there
+func (puzzle *Puzzle) applyHiddenSingles() bool {
+	valueAssigned := false
+
+	for _, house := range puzzle.houseIndexes {
+		for value := 1; value <= puzzle.underlyingGrid.maxElement(); value++ {
+			if puzzle.isValuePlacedInHouse(house, value) {
+				continue
+			}
+
+			cells := puzzle.cellsWithPossibility(house, value)
+			if len(cells) == 1 {
+				puzzle.assignValue(cells[0], value)
+				valueAssigned = true
+			}
+		}
+	}
+
+	return valueAssigned
+}
+
+// if all the possible places for a value in one house are also in a second house,
+// the value must go in the intersection, so it can be eliminated from the rest of the second house
+// covers both "pointing" (box -> row/column) and "claiming" (row/column -> box)
+func (puzzle *Puzzle) applyLockedCandidates() bool {
+	eliminationsMade := false
+
+	for _, house := range puzzle.houseIndexes {
+		for value := 1; value <= puzzle.underlyingGrid.maxElement(); value++ {
+			cells := puzzle.cellsWithPossibility(house, value)
+
+			// a single cell is a hidden single, not locked candidates
+			if len(cells) < 2 {
+				continue
+			}
+
+			for _, otherHouse := range puzzle.houseIndexes {
+				if !isSubset(cells, otherHouse) || slices.Equal(house, otherHouse) {
+					continue
+				}
+
+				for _, cellIndex := range otherHouse {
+					if slices.Contains(house, cellIndex) {
+						continue
+					}
+
+					if puzzle.eliminatePossibility(cellIndex, value) {
+						eliminationsMade = true
+					}
+				}
+			}
+		}
+	}
+
+	return eliminationsMade
+}
+
+// if `size` cells in a house have only `size` possibilities between them,
+// those possibilities must go in those cells, so they can be eliminated from the rest of the house
+func (puzzle *Puzzle) applyNakedSubsets(size int) bool {
+	eliminationsMade := false
+
+	for _, house := range puzzle.houseIndexes {
+		// only cells with between 2 and `size` possibilities can be part of a naked subset
+		subsetCandidates := []int{}
+		for _, cellIndex := range house {
+			numPossibilities := puzzle.possibleValues[cellIndex].Size()
+			if puzzle.isCellEmpty(cellIndex) && numPossibilities >= 2 && numPossibilities <= size {
+				subsetCandidates = append(subsetCandidates, cellIndex)
+			}
+		}
+
+		for _, subset := range utils.Combinations(subsetCandidates, size) {
+			combinedPossibilities := utils.Set[int]{}
+			for _, cellIndex := range subset {
+				for _, possibility := range puzzle.possibleValues[cellIndex].Elements() {
+					combinedPossibilities.Add(possibility)
+				}
+			}
+
+			if combinedPossibilities.Size() != size {
+				continue
+			}
+
+			for _, cellIndex := range house {
+				if slices.Contains(subset, cellIndex) {
+					continue
+				}
+
+				for _, possibility := range combinedPossibilities.Elements() {
+					if puzzle.eliminatePossibility(cellIndex, possibility) {
+						eliminationsMade = true
+					}
+				}
+			}
+		}
+	}
+
+	return eliminationsMade
+}
+
+// if `size` values can only go in the same `size` cells of a house,
+// those cells must hold those values, so any other possibilities can be eliminated from them
+func (puzzle *Puzzle) applyHiddenSubsets(size int) bool {
+	eliminationsMade := false
+
+	for _, house := range puzzle.houseIndexes {
+		unplacedValues := []int{}
+		for value := 1; value <= puzzle.underlyingGrid.maxElement(); value++ {
+			if !puzzle.isValuePlacedInHouse(house, value) {
+				unplacedValues = append(unplacedValues, value)
+			}
+		}
+
+		for _, subset := range utils.Combinations(unplacedValues, size) {
+			combinedCells := utils.Set[int]{}
+			for _, value := range subset {
+				for _, cellIndex := range puzzle.cellsWithPossibility(house, value) {
+					combinedCells.Add(cellIndex)
+				}
+			}
+
+			if combinedCells.Size() != size {
+				continue
+			}
+
+			for _, cellIndex := range combinedCells.Elements() {
+				for _, possibility := range puzzle.possibleValues[cellIndex].Elements() {
+					if slices.Contains(subset, possibility) {
+						continue
+					}
+
+					if puzzle.eliminatePossibility(cellIndex, possibility) {
+						eliminationsMade = true
+					}
+				}
+			}
+		}
+	}
+
+	return eliminationsMade
+}
+
+// X-Wing (size 2) and Swordfish (size 3):
+// if, in `size` rows, a value can only go in the same `size` columns,
+// then the value must go in those columns somewhere in those rows, so it can be eliminated from the rest of the columns
+// (and the same with rows and columns swapped)
+func (puzzle *Puzzle) applyFish(size int) bool {
+	eliminationsMade := false
+
+	sideLength := puzzle.underlyingGrid.sideLength()
+	rows := puzzle.houseIndexes[0:sideLength]
+	cols := puzzle.houseIndexes[sideLength : 2*sideLength]
+
+	rowOf := func(cellIndex int) int {
+		return cellIndex / sideLength
+	}
+	colOf := func(cellIndex int) int {
+		return cellIndex % sideLength
+	}
+
+	// baseLines are the lines the value is restricted in, coverLines are the lines it's eliminated from
+	// lineOf maps a cell to its position in coverLines
+	findFish := func(value int, baseLines [][]int, coverLines [][]int, lineOf func(int) int) {
+		// positions in coverLines where value can go, for each baseLine that can be part of a fish
+		basePositions := map[int][]int{}
+		for baseLineIndex, baseLine := range baseLines {
+			positions := []int{}
+			for _, cellIndex := range puzzle.cellsWithPossibility(baseLine, value) {
+				positions = append(positions, lineOf(cellIndex))
+			}
+
+			if len(positions) >= 2 && len(positions) <= size {
+				basePositions[baseLineIndex] = positions
+			}
+		}
+
+		fishCandidates := []int{}
+		for baseLineIndex := range baseLines {
+			if _, ok := basePositions[baseLineIndex]; ok {
+				fishCandidates = append(fishCandidates, baseLineIndex)
+			}
+		}
+
+		for _, chosenBaseLines := range utils.Combinations(fishCandidates, size) {
+			coverPositions := utils.Set[int]{}
+			for _, baseLineIndex := range chosenBaseLines {
+				for _, position := range basePositions[baseLineIndex] {
+					coverPositions.Add(position)
+				}
+			}
+
+			if coverPositions.Size() != size {
+				continue
+			}
+
+			for _, coverLineIndex := range coverPositions.Elements() {
+				for baseLineIndex, cellIndex := range coverLines[coverLineIndex] {
+					// cells in coverLines[i] are ordered by baseLine, so their index in the cover line is their base line
+					if slices.Contains(chosenBaseLines, baseLineIndex) {
+						continue
+					}
+
+					if puzzle.eliminatePossibility(cellIndex, value) {
+						eliminationsMade = true
+					}
+				}
+			}
+		}
+	}
+
+	for value := 1; value <= puzzle.underlyingGrid.maxElement(); value++ {
+		findFish(value, rows, cols, colOf)
+		findFish(value, cols, rows, rowOf)
+	}
+
+	return eliminationsMade
+}
+
+// if a cell (the pivot) has possibilities {X, Y}, and two of its peers (the pincers) have possibilities {X, Z} and {Y, Z},
+// then one of the pincers must be Z, so Z can be eliminated from every cell that's a peer of both pincers
+func (puzzle *Puzzle) applyXYWings() bool {
+	eliminationsMade := false
+
+	hasTwoPossibilities := func(cellIndex int) bool {
+		return puzzle.isCellEmpty(cellIndex) && puzzle.possibleValues[cellIndex].Size() == 2
+	}
+
+	for pivot := range puzzle.underlyingGrid.numCells() {
+		if !hasTwoPossibilities(pivot) {
+			continue
+		}
+
+		pivotPossibilities := puzzle.sortedPossibilities(pivot)
+		x, y := pivotPossibilities[0], pivotPossibilities[1]
+
+		pincerCandidates := []int{}
+		for _, peer := range puzzle.peerIndexes[pivot] {
+			if hasTwoPossibilities(peer) {
+				pincerCandidates = append(pincerCandidates, peer)
+			}
+		}
+		slices.Sort(pincerCandidates)
+
+		for _, pincers := range utils.Combinations(pincerCandidates, 2) {
+			xzPincer, yzPincer := pincers[0], pincers[1]
+
+			// the pincers can be in either order, so try both
+			for range 2 {
+				xzPossibilities := puzzle.possibleValues[xzPincer]
+				yzPossibilities := puzzle.possibleValues[yzPincer]
+
+				if xzPossibilities.Has(x) && !xzPossibilities.Has(y) && yzPossibilities.Has(y) && !yzPossibilities.Has(x) {
+					z := otherPossibility(&xzPossibilities, x)
+
+					if yzPossibilities.Has(z) {
+						for _, cellIndex := range puzzle.peerIndexes[xzPincer] {
+							if cellIndex == yzPincer || !slices.Contains(puzzle.peerIndexes[yzPincer], cellIndex) {
+								continue
+							}
+
+							if puzzle.eliminatePossibility(cellIndex, z) {
+								eliminationsMade = true
+							}
+						}
+					}
+				}
+
+				xzPincer, yzPincer = yzPincer, xzPincer
+			}
+		}
+	}
+
+	return eliminationsMade
+}
+
+// for a set with exactly 2 possibilities, one of which is `known`, returns the other one
+func otherPossibility(possibilities *utils.Set[int], known int) int {
+	for _, possibility := range possibilities.Elements() {
+		if possibility != known {
+			return possibility
+		}
+	}
+
+	panic("set didn't have a second possibility")
+}
+
+// checks if every element of subset is also in superset
+func isSubset(subset []int, superset []int) bool {
+	for _, element := range subset {
+		if !slices.Contains(superset, element) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,184 @@
+package sudoku
+
+import (
+	"slices"
+)
+
+// a symmetry of the pattern of givens in a challenge (only which cells are given, not their values)
+type Symmetry int
+
+const (
+	NoSymmetry         Symmetry = iota
+	Rotational180               // unchanged when rotated by 180 degrees
+	Rotational90                // unchanged when rotated by 90 degrees (which implies Rotational180)
+	HorizontalMirror            // unchanged when reflected across the horizontal axis, swapping top and bottom
+	VerticalMirror              // unchanged when reflected across the vertical axis, swapping left and right
+	DiagonalMirror              // unchanged when reflected across the main diagonal, from top-left to bottom-right
+	AntiDiagonalMirror          // unchanged when reflected across the anti-diagonal, from top-right to bottom-left
+	Dihedral                    // unchanged by every rotation and reflection of the square
+)
+
+// all symmetries other than NoSymmetry
+var allSymmetries = []Symmetry{
+	Rotational180,
+	Rotational90,
+	HorizontalMirror,
+	VerticalMirror,
+	DiagonalMirror,
+	AntiDiagonalMirror,
+	Dihedral,
+}
+
+func (s Symmetry) String() string {
+	switch s {
+	case NoSymmetry:
+		return "None"
+	case Rotational180:
+		return "180-degree rotational"
+	case Rotational90:
+		return "90-degree rotational"
+	case HorizontalMirror:
+		return "Horizontal mirror"
+	case VerticalMirror:
+		return "Vertical mirror"
+	case DiagonalMirror:
+		return "Diagonal mirror"
+	case AntiDiagonalMirror:
+		return "Anti-diagonal mirror"
+	case Dihedral:
+		return "Dihedral"
+	default:
+		return "unknown"
+	}
+}
+
+// maps a cell's (row, column) to the (row, column) of the cell it's moved to, in a grid with the given side length
+type cellTransformation func(row int, col int, sideLength int) (int, int)
+
+func rotate90(row int, col int, sideLength int) (int, int) {
+	return col, sideLength - 1 - row
+}
+
+func rotate180(row int, col int, sideLength int) (int, int) {
+	return sideLength - 1 - row, sideLength - 1 - col
+}
+
+func rotate270(row int, col int, sideLength int) (int, int) {
+	return sideLength - 1 - col, row
+}
+
+func reflectHorizontally(row int, col int, sideLength int) (int, int) {
+	return sideLength - 1 - row, col
+}
+
+func reflectVertically(row int, col int, sideLength int) (int, int) {
+	return row, sideLength - 1 - col
+}
+
+func reflectDiagonally(row int, col int, sideLength int) (int, int) {
+	return col, row
+}
+
+func reflectAntiDiagonally(row int, col int, sideLength int) (int, int) {
+	return sideLength - 1 - col, sideLength - 1 - row
+}
+
+// the non-identity transformations that make up the symmetry; applying them to a cell gives the rest of its orbit
+func (s Symmetry) transformations() []cellTransformation {
+	switch s {
+	case NoSymmetry:
+		return []cellTransformation{}
+	case Rotational180:
+		return []cellTransformation{rotate180}
+	case Rotational90:
+		return []cellTransformation{rotate90, rotate180, rotate270}
+	case HorizontalMirror:
+		return []cellTransformation{reflectHorizontally}
+	case VerticalMirror:
+		return []cellTransformation{reflectVertically}
+	case DiagonalMirror:
+		return []cellTransformation{reflectDiagonally}
+	case AntiDiagonalMirror:
+		return []cellTransformation{reflectAntiDiagonally}
+	case Dihedral:
+		return []cellTransformation{
+			rotate90,
+			rotate180,
+			rotate270,
+			reflectHorizontally,
+			reflectVertically,
+			reflectDiagonally,
+			reflectAntiDiagonally,
+		}
+	default:
+		panic("unknown symmetry")
+	}
+}
+
+// indexes of all cells that the symmetry maps the cell at cellIndex to, including cellIndex itself, in increasing order
+// for a symmetric pattern of givens, either every cell in an orbit is a given or none of them are
+func (s Symmetry) orbit(cellIndex int, sideLength int) []int {
+	row, col := cellIndex/sideLength, cellIndex%sideLength
+
+	orbit := []int{cellIndex}
+	for _, transform := range s.transformations() {
+		transformedRow, transformedCol := transform(row, col, sideLength)
+		transformedIndex := transformedRow*sideLength + transformedCol
+
+		if !slices.Contains(orbit, transformedIndex) {
+			orbit = append(orbit, transformedIndex)
+		}
+	}
+
+	slices.Sort(orbit)
+	return orbit
+}
+
+// splits all cells of a grid into the symmetry's orbits, visiting cells in the order given by cellOrder
+// each cell appears in exactly one orbit; orbits are ordered by when their first cell appears in cellOrder
+func (s Symmetry) orbits(cellOrder []int, sideLength int) [][]int {
+	orbits := [][]int{}
+	visited := make([]bool, len(cellOrder))
+
+	for _, cellIndex := range cellOrder {
+		if visited[cellIndex] {
+			continue
+		}
+
+		orbit := s.orbit(cellIndex, sideLength)
+		for _, orbitCellIndex := range orbit {
+			visited[orbitCellIndex] = true
+		}
+
+		orbits = append(orbits, orbit)
+	}
+
+	return orbits
+}
+
+// finds every symmetry that the pattern of givens in grid has
+// NoSymmetry isn't included (every pattern trivially has it), so an empty result means the pattern isn't symmetric at all
+func DetectClueSymmetry(grid Grid) []Symmetry {
+	symmetries := []Symmetry{}
+
+	for _, symmetry := range allSymmetries {
+		if hasClueSymmetry(grid, symmetry) {
+			symmetries = append(symmetries, symmetry)
+		}
+	}
+
+	return symmetries
+}
+
+// checks if every orbit of the symmetry is either entirely givens or entirely empty
+func hasClueSymmetry(grid Grid, symmetry Symmetry) bool {
+	for i := range grid.numCells() {
+		for _, orbitCellIndex := range symmetry.orbit(i, grid.sideLength()) {
+			if grid.isEmptyAt(orbitCellIndex) != grid.isEmptyAt(i) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,68 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectClueSymmetry(t *testing.T) {
+	t.Run("A pattern with only 180-degree rotational symmetry", func(t *testing.T) {
+		// givens at (0, 1), (3, 2)
+		grid := sudoku.ParseSingleGrid(".1............2.")
+
+		assert.ElementsMatch(t, []sudoku.Symmetry{sudoku.Rotational180}, sudoku.DetectClueSymmetry(grid))
+	})
+
+	t.Run("A pattern with every symmetry", func(t *testing.T) {
+		// givens in the four corners
+		grid := sudoku.ParseSingleGrid("1..2........2..1")
+
+		expectedSymmetries := []sudoku.Symmetry{
+			sudoku.Rotational180,
+			sudoku.Rotational90,
+			sudoku.HorizontalMirror,
+			sudoku.VerticalMirror,
+			sudoku.DiagonalMirror,
+			sudoku.AntiDiagonalMirror,
+			sudoku.Dihedral,
+		}
+		assert.ElementsMatch(t, expectedSymmetries, sudoku.DetectClueSymmetry(grid))
+	})
+
+	t.Run("A pattern with only mirror symmetry across the main diagonal", func(t *testing.T) {
+		// givens at (0, 1), (1, 0)
+		grid := sudoku.ParseSingleGrid(".1..2...........")
+
+		assert.ElementsMatch(t, []sudoku.Symmetry{sudoku.DiagonalMirror}, sudoku.DetectClueSymmetry(grid))
+	})
+
+	t.Run("An asymmetric pattern", func(t *testing.T) {
+		grid := sudoku.ParseSingleGrid("12..............")
+
+		assert.Empty(t, sudoku.DetectClueSymmetry(grid))
+	})
+}
+
+func TestGenerateWithSymmetry(t *testing.T) {
+	symmetries := []sudoku.Symmetry{
+		sudoku.Rotational180,
+		sudoku.Rotational90,
+		sudoku.HorizontalMirror,
+		sudoku.VerticalMirror,
+		sudoku.DiagonalMirror,
+		sudoku.AntiDiagonalMirror,
+		sudoku.Dihedral,
+	}
+
+	for _, symmetry := range symmetries {
+		t.Run(symmetry.String(), func(t *testing.T) {
+			challenge, err := sudoku.Generate(3, sudoku.GeneratorOptions{Seed: 1, Symmetry: symmetry})
+			assert.NoError(t, err)
+
+			assert.True(t, sudoku.HasUniqueSolution(challenge))
+			assert.Contains(t, sudoku.DetectClueSymmetry(challenge), symmetry)
+		})
+	}
+}
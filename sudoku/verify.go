@@ -0,0 +1,61 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// returned when an operation needs two grids with the same size and box shape (or two Latin squares of the same size), but got different ones
+var ErrGeometryMismatch = errors.New("grids have different sizes or box shapes")
+
+// a given from a challenge that a supposed solution doesn't keep
+type ViolatedGiven struct {
+	CellIndex int
+
+	Given  int // the value in the challenge
+	Actual int // the value in the solution, or 0 if the solution left the cell empty
+}
+
+func (v ViolatedGiven) String() string {
+	return fmt.Sprintf("cell %v should be %v, but is %v", v.CellIndex, v.Given, v.Actual)
+}
+
+// checks if a and b have the same size and box shape, so that their cells line up
+func haveSameGeometry(a Grid, b Grid) bool {
+	return a.size == b.size && a.boxWidth == b.boxWidth && a.boxHeight == b.boxHeight
+}
+
+// lists every given in challenge that solution changes or leaves empty, in order of cell index
+// solution doesn't have to be complete or valid; only the givens are checked
+// returns ErrGeometryMismatch if the grids' cells don't line up
+func ViolatedGivens(challenge Grid, solution Grid) ([]ViolatedGiven, error) {
+	if !haveSameGeometry(challenge, solution) {
+		return nil, ErrGeometryMismatch
+	}
+
+	violations := []ViolatedGiven{}
+	for i := range challenge.numCells() {
+		if challenge.isEmptyAt(i) || challenge.valueAt(i) == solution.valueAt(i) {
+			continue
+		}
+
+		violations = append(violations, ViolatedGiven{
+			CellIndex: i,
+			Given:     challenge.valueAt(i),
+			Actual:    solution.valueAt(i),
+		})
+	}
+
+	return violations, nil
+}
+
+// checks if solution is a valid solution that keeps every given from challenge
+// doesn't check whether challenge has other solutions; use HasUniqueSolution() for that
+func IsSolutionOf(challenge Grid, solution Grid) bool {
+	if !solution.IsValidSolution() {
+		return false
+	}
+
+	violations, err := ViolatedGivens(challenge, solution)
+	return err == nil && len(violations) == 0
+}
@@ -0,0 +1,69 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/DylanSp/sudoku-toolkit/sudoku"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSolutionOf(t *testing.T) {
+	challenge := sudoku.ParseSingleGrid("1......4..2..3..")
+
+	t.Run("Checking solutions", func(t *testing.T) {
+		solution := sudoku.ParseSingleGrid("1432321441232341")
+		assert.True(t, sudoku.IsSolutionOf(challenge, solution))
+
+		// a solution always solves itself, and any solution solves the empty grid
+		assert.True(t, sudoku.IsSolutionOf(solution, solution))
+		assert.True(t, sudoku.IsSolutionOf(sudoku.EmptyGrid(2), solution))
+	})
+
+	t.Run("A valid grid with different givens isn't a solution", func(t *testing.T) {
+		otherSolution := sudoku.ParseSingleGrid("1234341221434321")
+		assert.True(t, otherSolution.IsValidSolution())
+		assert.False(t, sudoku.IsSolutionOf(challenge, otherSolution))
+	})
+
+	t.Run("An incomplete grid isn't a solution", func(t *testing.T) {
+		assert.False(t, sudoku.IsSolutionOf(challenge, challenge))
+	})
+
+	t.Run("Grids with different shapes aren't solutions", func(t *testing.T) {
+		latinSquare := sudoku.ParseLatinSquare("1432321441232341")
+		assert.True(t, latinSquare.IsValidSolution())
+		assert.False(t, sudoku.IsSolutionOf(challenge, latinSquare))
+		assert.False(t, sudoku.IsSolutionOf(sudoku.EmptyGrid(3), latinSquare))
+	})
+}
+
+func TestViolatedGivens(t *testing.T) {
+	challenge := sudoku.ParseSingleGrid("1......4..2..3..")
+
+	t.Run("Reporting violated givens", func(t *testing.T) {
+		// changes the given 1 to a 3 and leaves the given 2 empty, but keeps the other givens
+		submission := sudoku.ParseSingleGrid("3......4.....3..")
+
+		violations, err := sudoku.ViolatedGivens(challenge, submission)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []sudoku.ViolatedGiven{
+			{CellIndex: 0, Given: 1, Actual: 3},
+			{CellIndex: 10, Given: 2, Actual: 0},
+		}, violations)
+		assert.EqualValues(t, "cell 0 should be 1, but is 3", violations[0].String())
+	})
+
+	t.Run("Keeping every given", func(t *testing.T) {
+		violations, err := sudoku.ViolatedGivens(challenge, sudoku.ParseSingleGrid("12.3...4..2..3.."))
+		assert.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("Grids with different shapes return an error", func(t *testing.T) {
+		_, err := sudoku.ViolatedGivens(challenge, sudoku.EmptyGrid(3))
+		assert.ErrorIs(t, err, sudoku.ErrGeometryMismatch)
+
+		_, err = sudoku.ViolatedGivens(challenge, sudoku.EmptyLatinSquare(4))
+		assert.ErrorIs(t, err, sudoku.ErrGeometryMismatch)
+	})
+}
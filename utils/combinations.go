@@ -0,0 +1,39 @@
+package utils
+
+// returns all ways of choosing `size` elements from items, preserving the order elements appear in items
+// e.g. Combinations([]int{1, 2, 3}, 2) returns [[1 2] [1 3] [2 3]]
+func Combinations[T any](items []T, size int) [][]T {
+	combinations := [][]T{}
+
+	if size < 0 || size > len(items) {
+		return combinations
+	}
+
+	// indexes into items for the current combination; starts as [0, 1, ..., size - 1]
+	chosenIndexes := make([]int, size)
+	for i := range chosenIndexes {
+		chosenIndexes[i] = i
+	}
+
+	for {
+		combination := make([]T, size)
+		for i, itemIndex := range chosenIndexes {
+			combination[i] = items[itemIndex]
+		}
+		combinations = append(combinations, combination)
+
+		// find the rightmost index that can still be advanced, advance it, then reset every index after it
+		i := size - 1
+		for i >= 0 && chosenIndexes[i] == len(items)-size+i {
+			i--
+		}
+		if i < 0 {
+			return combinations
+		}
+
+		chosenIndexes[i]++
+		for j := i + 1; j < size; j++ {
+			chosenIndexes[j] = chosenIndexes[j-1] + 1
+		}
+	}
+}
@@ -46,6 +46,19 @@ func (s *Set[T]) Elements() []T {
 	return elements
 }
 
+// returns a new set with the same elements; mutating the clone doesn't affect the original, and vice versa
+func (s *Set[T]) Clone() Set[T] {
+	clone := Set[T]{}
+
+	if s != nil && s.underlying != nil {
+		for element := range s.underlying {
+			clone.Add(element)
+		}
+	}
+
+	return clone
+}
+
 // mutating methods
 
 func (s *Set[T]) Add(element T) {